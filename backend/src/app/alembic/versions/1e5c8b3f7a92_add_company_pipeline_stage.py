@@ -0,0 +1,33 @@
+"""Add company pipeline stage
+
+Revision ID: 1e5c8b3f7a92
+Revises: 7d2a9c4e6f18
+Create Date: 2025-02-24 11:33:52.108716
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '1e5c8b3f7a92'
+down_revision = '7d2a9c4e6f18'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'company',
+        sa.Column(
+            'pipeline_stage',
+            sqlmodel.sql.sqltypes.AutoString(),
+            nullable=False,
+            server_default='sourced',
+        ),
+    )
+
+
+def downgrade():
+    op.drop_column('company', 'pipeline_stage')
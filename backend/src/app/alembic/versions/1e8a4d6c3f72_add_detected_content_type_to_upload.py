@@ -0,0 +1,31 @@
+"""Add detected content type to upload
+
+Revision ID: 1e8a4d6c3f72
+Revises: 9f3c7e5a1b84
+Create Date: 2025-03-26 15:33:54.287419
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '1e8a4d6c3f72'
+down_revision = '9f3c7e5a1b84'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'uploadedfile',
+        sa.Column(
+            'detected_content_type', sqlmodel.sql.sqltypes.AutoString(length=255),
+            nullable=True,
+        ),
+    )
+
+
+def downgrade():
+    op.drop_column('uploadedfile', 'detected_content_type')
@@ -0,0 +1,45 @@
+"""Add exit comp
+
+Revision ID: 2c9f5b8e4a61
+Revises: 8b6e3c1f9d47
+Create Date: 2025-03-17 09:27:43.615208
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '2c9f5b8e4a61'
+down_revision = '8b6e3c1f9d47'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'exitcomp',
+        sa.Column(
+            'company_name', sqlmodel.sql.sqltypes.AutoString(length=255),
+            nullable=False,
+        ),
+        sa.Column(
+            'industry', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=False
+        ),
+        sa.Column('stage', sqlmodel.sql.sqltypes.AutoString(), nullable=True),
+        sa.Column('exit_type', sqlmodel.sql.sqltypes.AutoString(), nullable=False),
+        sa.Column(
+            'acquirer', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=True
+        ),
+        sa.Column('exit_value_usd', sa.Float(), nullable=True),
+        sa.Column('revenue_multiple', sa.Float(), nullable=True),
+        sa.Column('exited_on', sa.Date(), nullable=False),
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.PrimaryKeyConstraint('id'),
+    )
+
+
+def downgrade():
+    op.drop_table('exitcomp')
@@ -0,0 +1,41 @@
+"""Add vector index jobs
+
+Revision ID: 2e96c4d7f8b3
+Revises: d7b58e2c4a19
+Create Date: 2025-02-05 13:22:51.904716
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '2e96c4d7f8b3'
+down_revision = 'd7b58e2c4a19'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'vectorindexjob',
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('operation', sqlmodel.sql.sqltypes.AutoString(), nullable=False),
+        sa.Column('company_id', sa.Uuid(), nullable=True),
+        sa.Column('organization_id', sa.Uuid(), nullable=True),
+        sa.Column('status', sqlmodel.sql.sqltypes.AutoString(), nullable=False),
+        sa.Column('progress', sa.Integer(), nullable=False),
+        sa.Column('error', sqlmodel.sql.sqltypes.AutoString(length=2000), nullable=True),
+        sa.Column('created_by', sa.Uuid(), nullable=False),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.Column('completed_at', sa.DateTime(), nullable=True),
+        sa.ForeignKeyConstraint(['company_id'], ['company.id'], ondelete='CASCADE'),
+        sa.ForeignKeyConstraint(['organization_id'], ['organization.id'], ondelete='CASCADE'),
+        sa.ForeignKeyConstraint(['created_by'], ['user.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+    )
+
+
+def downgrade():
+    op.drop_table('vectorindexjob')
@@ -0,0 +1,30 @@
+"""Add company github org
+
+Revision ID: 2f8a4d6c9e31
+Revises: 8c6f2e9b4a17
+Create Date: 2025-03-05 15:42:09.613284
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '2f8a4d6c9e31'
+down_revision = '8c6f2e9b4a17'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'company',
+        sa.Column(
+            'github_org', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=True
+        ),
+    )
+
+
+def downgrade():
+    op.drop_column('company', 'github_org')
@@ -0,0 +1,52 @@
+"""Add tags
+
+Revision ID: 3a9d7e5c2b64
+Revises: 1e5c8b3f7a92
+Create Date: 2025-02-26 09:41:17.552903
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '3a9d7e5c2b64'
+down_revision = '1e5c8b3f7a92'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'tag',
+        sa.Column('name', sqlmodel.sql.sqltypes.AutoString(length=100), nullable=False),
+        sa.Column('color', sqlmodel.sql.sqltypes.AutoString(length=7), nullable=True),
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('owner_id', sa.Uuid(), nullable=False),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.ForeignKeyConstraint(['owner_id'], ['user.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+    )
+    op.create_table(
+        'companytag',
+        sa.Column('company_id', sa.Uuid(), nullable=False),
+        sa.Column('tag_id', sa.Uuid(), nullable=False),
+        sa.ForeignKeyConstraint(['company_id'], ['company.id'], ondelete='CASCADE'),
+        sa.ForeignKeyConstraint(['tag_id'], ['tag.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('company_id', 'tag_id'),
+    )
+    op.create_table(
+        'analysistag',
+        sa.Column('analysis_id', sa.Uuid(), nullable=False),
+        sa.Column('tag_id', sa.Uuid(), nullable=False),
+        sa.ForeignKeyConstraint(['analysis_id'], ['analysis.id'], ondelete='CASCADE'),
+        sa.ForeignKeyConstraint(['tag_id'], ['tag.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('analysis_id', 'tag_id'),
+    )
+
+
+def downgrade():
+    op.drop_table('analysistag')
+    op.drop_table('companytag')
+    op.drop_table('tag')
@@ -0,0 +1,47 @@
+"""Add portfolio
+
+Revision ID: 3c7e9a5d1f48
+Revises: 7a4c8e2f6d93
+Create Date: 2025-03-13 09:36:14.528471
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '3c7e9a5d1f48'
+down_revision = '7a4c8e2f6d93'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'portfolio',
+        sa.Column('name', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=False),
+        sa.Column(
+            'description', sqlmodel.sql.sqltypes.AutoString(length=2000), nullable=True
+        ),
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('owner_id', sa.Uuid(), nullable=False),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.ForeignKeyConstraint(['owner_id'], ['user.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+    )
+    op.create_table(
+        'portfoliocompany',
+        sa.Column('portfolio_id', sa.Uuid(), nullable=False),
+        sa.Column('company_id', sa.Uuid(), nullable=False),
+        sa.ForeignKeyConstraint(['company_id'], ['company.id'], ondelete='CASCADE'),
+        sa.ForeignKeyConstraint(
+            ['portfolio_id'], ['portfolio.id'], ondelete='CASCADE'
+        ),
+        sa.PrimaryKeyConstraint('portfolio_id', 'company_id'),
+    )
+
+
+def downgrade():
+    op.drop_table('portfoliocompany')
+    op.drop_table('portfolio')
@@ -0,0 +1,34 @@
+"""Add queue pause
+
+Revision ID: 3f9a12c87e04
+Revises: b8d4e6a1f520
+Create Date: 2025-01-20 15:41:12.330871
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = '3f9a12c87e04'
+down_revision = 'b8d4e6a1f520'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'queuepause',
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('organization_id', sa.Uuid(), nullable=True),
+        sa.Column('paused_by', sa.Uuid(), nullable=False),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.ForeignKeyConstraint(['organization_id'], ['organization.id'], ondelete='CASCADE'),
+        sa.ForeignKeyConstraint(['paused_by'], ['user.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+        sa.UniqueConstraint('organization_id'),
+    )
+
+
+def downgrade():
+    op.drop_table('queuepause')
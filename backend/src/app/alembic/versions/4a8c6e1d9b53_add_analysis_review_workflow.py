@@ -0,0 +1,54 @@
+"""Add analysis review workflow
+
+Revision ID: 4a8c6e1d9b53
+Revises: 7e4b9d2c6f18
+Create Date: 2025-03-18 11:05:29.174836
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '4a8c6e1d9b53'
+down_revision = '7e4b9d2c6f18'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'analysis',
+        sa.Column(
+            'review_state', sqlmodel.sql.sqltypes.AutoString(), nullable=False,
+            server_default='draft',
+        ),
+    )
+    op.add_column('analysis', sa.Column('reviewer_id', sa.Uuid(), nullable=True))
+    op.add_column(
+        'analysis',
+        sa.Column(
+            'review_note', sqlmodel.sql.sqltypes.AutoString(length=2000),
+            nullable=True,
+        ),
+    )
+    op.add_column(
+        'analysis', sa.Column('reviewed_at', sa.DateTime(), nullable=True)
+    )
+    op.create_foreign_key(
+        'analysis_reviewer_id_fkey',
+        'analysis',
+        'user',
+        ['reviewer_id'],
+        ['id'],
+        ondelete='SET NULL',
+    )
+
+
+def downgrade():
+    op.drop_constraint('analysis_reviewer_id_fkey', 'analysis')
+    op.drop_column('analysis', 'reviewed_at')
+    op.drop_column('analysis', 'review_note')
+    op.drop_column('analysis', 'reviewer_id')
+    op.drop_column('analysis', 'review_state')
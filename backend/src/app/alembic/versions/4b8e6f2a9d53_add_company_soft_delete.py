@@ -0,0 +1,24 @@
+"""Add company soft delete
+
+Revision ID: 4b8e6f2a9d53
+Revises: 9f7c2d5b8a41
+Create Date: 2025-02-19 14:41:28.573861
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = '4b8e6f2a9d53'
+down_revision = '9f7c2d5b8a41'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column('company', sa.Column('deleted_at', sa.DateTime(), nullable=True))
+
+
+def downgrade():
+    op.drop_column('company', 'deleted_at')
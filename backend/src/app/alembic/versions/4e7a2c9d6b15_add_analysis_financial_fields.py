@@ -0,0 +1,32 @@
+"""Add analysis financial fields
+
+Revision ID: 4e7a2c9d6b15
+Revises: 9d5c1f8b3e62
+Create Date: 2025-03-10 11:08:37.441906
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = '4e7a2c9d6b15'
+down_revision = '9d5c1f8b3e62'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'analysis', sa.Column('monthly_burn_usd', sa.Float(), nullable=True)
+    )
+    op.add_column(
+        'analysis', sa.Column('cash_on_hand_usd', sa.Float(), nullable=True)
+    )
+    op.add_column('analysis', sa.Column('runway_months', sa.Float(), nullable=True))
+
+
+def downgrade():
+    op.drop_column('analysis', 'runway_months')
+    op.drop_column('analysis', 'cash_on_hand_usd')
+    op.drop_column('analysis', 'monthly_burn_usd')
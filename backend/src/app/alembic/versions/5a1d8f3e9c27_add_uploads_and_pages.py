@@ -0,0 +1,52 @@
+"""Add uploads and pages
+
+Revision ID: 5a1d8f3e9c27
+Revises: 2e96c4d7f8b3
+Create Date: 2025-02-07 10:36:18.443092
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '5a1d8f3e9c27'
+down_revision = '2e96c4d7f8b3'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'uploadedfile',
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('filename', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=False),
+        sa.Column('content_type', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=False),
+        sa.Column('size', sa.Integer(), nullable=False),
+        sa.Column('storage_path', sqlmodel.sql.sqltypes.AutoString(length=1024), nullable=False),
+        sa.Column('status', sqlmodel.sql.sqltypes.AutoString(), nullable=False),
+        sa.Column('error', sqlmodel.sql.sqltypes.AutoString(length=2000), nullable=True),
+        sa.Column('page_count', sa.Integer(), nullable=True),
+        sa.Column('company_id', sa.Uuid(), nullable=True),
+        sa.Column('owner_id', sa.Uuid(), nullable=False),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.ForeignKeyConstraint(['company_id'], ['company.id'], ondelete='CASCADE'),
+        sa.ForeignKeyConstraint(['owner_id'], ['user.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+    )
+    op.create_table(
+        'uploadpage',
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('upload_id', sa.Uuid(), nullable=False),
+        sa.Column('number', sa.Integer(), nullable=False),
+        sa.Column('text', sqlmodel.sql.sqltypes.AutoString(), nullable=False),
+        sa.Column('image_path', sqlmodel.sql.sqltypes.AutoString(length=1024), nullable=True),
+        sa.ForeignKeyConstraint(['upload_id'], ['uploadedfile.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+    )
+
+
+def downgrade():
+    op.drop_table('uploadpage')
+    op.drop_table('uploadedfile')
@@ -0,0 +1,26 @@
+"""Add company stage changed at
+
+Revision ID: 5b9d2f7e4c13
+Revises: 1e8a4d6c3f72
+Create Date: 2025-03-28 09:07:22.643158
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = '5b9d2f7e4c13'
+down_revision = '1e8a4d6c3f72'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'company', sa.Column('stage_changed_at', sa.DateTime(), nullable=True)
+    )
+
+
+def downgrade():
+    op.drop_column('company', 'stage_changed_at')
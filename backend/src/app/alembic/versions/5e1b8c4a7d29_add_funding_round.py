@@ -0,0 +1,36 @@
+"""Add funding round
+
+Revision ID: 5e1b8c4a7d29
+Revises: 3a9d7e5c2b64
+Create Date: 2025-02-27 14:06:48.290115
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '5e1b8c4a7d29'
+down_revision = '3a9d7e5c2b64'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'fundinground',
+        sa.Column('stage', sqlmodel.sql.sqltypes.AutoString(), nullable=False),
+        sa.Column('amount_usd', sa.Float(), nullable=True),
+        sa.Column('announced_on', sa.Date(), nullable=False),
+        sa.Column('investors', sa.JSON(), nullable=False),
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('company_id', sa.Uuid(), nullable=False),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.ForeignKeyConstraint(['company_id'], ['company.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+    )
+
+
+def downgrade():
+    op.drop_table('fundinground')
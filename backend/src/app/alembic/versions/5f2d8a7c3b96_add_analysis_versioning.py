@@ -0,0 +1,40 @@
+"""Add analysis versioning
+
+Revision ID: 5f2d8a7c3b96
+Revises: 3c7e9a5d1f48
+Create Date: 2025-03-14 10:12:37.846205
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = '5f2d8a7c3b96'
+down_revision = '3c7e9a5d1f48'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'analysis',
+        sa.Column('version', sa.Integer(), nullable=False, server_default='1'),
+    )
+    op.add_column(
+        'analysis', sa.Column('supersedes_id', sa.Uuid(), nullable=True)
+    )
+    op.create_foreign_key(
+        'analysis_supersedes_id_fkey',
+        'analysis',
+        'analysis',
+        ['supersedes_id'],
+        ['id'],
+        ondelete='SET NULL',
+    )
+
+
+def downgrade():
+    op.drop_constraint('analysis_supersedes_id_fkey', 'analysis')
+    op.drop_column('analysis', 'supersedes_id')
+    op.drop_column('analysis', 'version')
@@ -0,0 +1,30 @@
+"""Add company app store id
+
+Revision ID: 6b3d9f1e5c84
+Revises: 2f8a4d6c9e31
+Create Date: 2025-03-06 09:27:43.195627
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '6b3d9f1e5c84'
+down_revision = '2f8a4d6c9e31'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'company',
+        sa.Column(
+            'app_store_id', sqlmodel.sql.sqltypes.AutoString(length=32), nullable=True
+        ),
+    )
+
+
+def downgrade():
+    op.drop_column('company', 'app_store_id')
@@ -0,0 +1,39 @@
+"""Add report template
+
+Revision ID: 6c4e91b7d2a8
+Revises: 8b3f6d1a4c95
+Create Date: 2025-02-13 11:24:45.310278
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '6c4e91b7d2a8'
+down_revision = '8b3f6d1a4c95'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'reporttemplate',
+        sa.Column('name', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=False),
+        sa.Column('sections', sa.JSON(), nullable=True),
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('organization_id', sa.Uuid(), nullable=False),
+        sa.Column('is_active', sa.Boolean(), nullable=False),
+        sa.Column('created_by', sa.Uuid(), nullable=False),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.ForeignKeyConstraint(
+            ['organization_id'], ['organization.id'], ondelete='CASCADE'
+        ),
+        sa.ForeignKeyConstraint(['created_by'], ['user.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+    )
+
+
+def downgrade():
+    op.drop_table('reporttemplate')
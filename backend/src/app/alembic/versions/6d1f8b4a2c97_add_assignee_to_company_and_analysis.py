@@ -0,0 +1,44 @@
+"""Add assignee to company and analysis
+
+Revision ID: 6d1f8b4a2c97
+Revises: 4a8c6e1d9b53
+Create Date: 2025-03-21 09:42:13.508214
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = '6d1f8b4a2c97'
+down_revision = '4a8c6e1d9b53'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column('company', sa.Column('assignee_id', sa.Uuid(), nullable=True))
+    op.add_column('analysis', sa.Column('assignee_id', sa.Uuid(), nullable=True))
+    op.create_foreign_key(
+        'company_assignee_id_fkey',
+        'company',
+        'user',
+        ['assignee_id'],
+        ['id'],
+        ondelete='SET NULL',
+    )
+    op.create_foreign_key(
+        'analysis_assignee_id_fkey',
+        'analysis',
+        'user',
+        ['assignee_id'],
+        ['id'],
+        ondelete='SET NULL',
+    )
+
+
+def downgrade():
+    op.drop_constraint('analysis_assignee_id_fkey', 'analysis')
+    op.drop_constraint('company_assignee_id_fkey', 'company')
+    op.drop_column('analysis', 'assignee_id')
+    op.drop_column('company', 'assignee_id')
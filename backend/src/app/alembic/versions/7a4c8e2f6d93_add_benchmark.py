@@ -0,0 +1,36 @@
+"""Add benchmark
+
+Revision ID: 7a4c8e2f6d93
+Revises: 4e7a2c9d6b15
+Create Date: 2025-03-12 13:54:21.073568
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '7a4c8e2f6d93'
+down_revision = '4e7a2c9d6b15'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'benchmark',
+        sa.Column(
+            'industry', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=False
+        ),
+        sa.Column('stage', sqlmodel.sql.sqltypes.AutoString(), nullable=True),
+        sa.Column('metric', sqlmodel.sql.sqltypes.AutoString(length=64), nullable=False),
+        sa.Column('values', sa.JSON(), nullable=False),
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.PrimaryKeyConstraint('id'),
+    )
+
+
+def downgrade():
+    op.drop_table('benchmark')
@@ -0,0 +1,27 @@
+"""Add company archived
+
+Revision ID: 7d2a9c4e6f18
+Revises: 4b8e6f2a9d53
+Create Date: 2025-02-21 09:05:16.920447
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = '7d2a9c4e6f18'
+down_revision = '4b8e6f2a9d53'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'company',
+        sa.Column('archived', sa.Boolean(), nullable=False, server_default='false'),
+    )
+
+
+def downgrade():
+    op.drop_column('company', 'archived')
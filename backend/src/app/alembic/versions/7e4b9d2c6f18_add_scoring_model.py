@@ -0,0 +1,40 @@
+"""Add scoring model
+
+Revision ID: 7e4b9d2c6f18
+Revises: 2c9f5b8e4a61
+Create Date: 2025-03-17 15:39:06.482917
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '7e4b9d2c6f18'
+down_revision = '2c9f5b8e4a61'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'scoringmodel',
+        sa.Column('name', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=False),
+        sa.Column('definition', sa.JSON(), nullable=False),
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('organization_id', sa.Uuid(), nullable=False),
+        sa.Column('version', sa.Integer(), nullable=False),
+        sa.Column('is_active', sa.Boolean(), nullable=False),
+        sa.Column('created_by', sa.Uuid(), nullable=False),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.ForeignKeyConstraint(
+            ['organization_id'], ['organization.id'], ondelete='CASCADE'
+        ),
+        sa.ForeignKeyConstraint(['created_by'], ['user.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+    )
+
+
+def downgrade():
+    op.drop_table('scoringmodel')
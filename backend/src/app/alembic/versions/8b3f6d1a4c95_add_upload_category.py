@@ -0,0 +1,33 @@
+"""Add upload category
+
+Revision ID: 8b3f6d1a4c95
+Revises: 5a1d8f3e9c27
+Create Date: 2025-02-10 09:58:02.651837
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '8b3f6d1a4c95'
+down_revision = '5a1d8f3e9c27'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'uploadedfile',
+        sa.Column(
+            'category',
+            sqlmodel.sql.sqltypes.AutoString(),
+            nullable=False,
+            server_default='other',
+        ),
+    )
+
+
+def downgrade():
+    op.drop_column('uploadedfile', 'category')
@@ -0,0 +1,39 @@
+"""Add analysis schedule
+
+Revision ID: 8b6e3c1f9d47
+Revises: 5f2d8a7c3b96
+Create Date: 2025-03-14 16:48:52.391764
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '8b6e3c1f9d47'
+down_revision = '5f2d8a7c3b96'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'analysisschedule',
+        sa.Column('frequency', sqlmodel.sql.sqltypes.AutoString(), nullable=False),
+        sa.Column('enabled', sa.Boolean(), nullable=False),
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('company_id', sa.Uuid(), nullable=False),
+        sa.Column('created_by', sa.Uuid(), nullable=False),
+        sa.Column('next_run_at', sa.DateTime(), nullable=False),
+        sa.Column('last_run_at', sa.DateTime(), nullable=True),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.ForeignKeyConstraint(['company_id'], ['company.id'], ondelete='CASCADE'),
+        sa.ForeignKeyConstraint(['created_by'], ['user.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+        sa.UniqueConstraint('company_id'),
+    )
+
+
+def downgrade():
+    op.drop_table('analysisschedule')
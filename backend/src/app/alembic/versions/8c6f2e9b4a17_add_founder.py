@@ -0,0 +1,38 @@
+"""Add founder
+
+Revision ID: 8c6f2e9b4a17
+Revises: 5e1b8c4a7d29
+Create Date: 2025-03-03 10:19:26.847301
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '8c6f2e9b4a17'
+down_revision = '5e1b8c4a7d29'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'founder',
+        sa.Column('name', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=False),
+        sa.Column('title', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=True),
+        sa.Column(
+            'linkedin_url', sqlmodel.sql.sqltypes.AutoString(length=512), nullable=True
+        ),
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('company_id', sa.Uuid(), nullable=False),
+        sa.Column('profile', sa.JSON(), nullable=False),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.ForeignKeyConstraint(['company_id'], ['company.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+    )
+
+
+def downgrade():
+    op.drop_table('founder')
@@ -0,0 +1,40 @@
+"""Add webhook delivery outbox
+
+Revision ID: 9d2b6c3e14f7
+Revises: c5e80d7f2b16
+Create Date: 2025-01-24 14:18:56.201394
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '9d2b6c3e14f7'
+down_revision = 'c5e80d7f2b16'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'webhookdelivery',
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('webhook_id', sa.Uuid(), nullable=False),
+        sa.Column('event_type', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=False),
+        sa.Column('payload', sa.JSON(), nullable=True),
+        sa.Column('status', sqlmodel.sql.sqltypes.AutoString(), nullable=False),
+        sa.Column('attempts', sa.Integer(), nullable=False),
+        sa.Column('next_attempt_at', sa.DateTime(), nullable=False),
+        sa.Column('last_error', sqlmodel.sql.sqltypes.AutoString(length=2000), nullable=True),
+        sa.Column('response_status', sa.Integer(), nullable=True),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.Column('delivered_at', sa.DateTime(), nullable=True),
+        sa.ForeignKeyConstraint(['webhook_id'], ['webhook.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+    )
+
+
+def downgrade():
+    op.drop_table('webhookdelivery')
@@ -0,0 +1,30 @@
+"""Add company twitter handle
+
+Revision ID: 9d5c1f8b3e62
+Revises: 6b3d9f1e5c84
+Create Date: 2025-03-07 16:31:55.824019
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '9d5c1f8b3e62'
+down_revision = '6b3d9f1e5c84'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'company',
+        sa.Column(
+            'twitter_handle', sqlmodel.sql.sqltypes.AutoString(length=64), nullable=True
+        ),
+    )
+
+
+def downgrade():
+    op.drop_column('company', 'twitter_handle')
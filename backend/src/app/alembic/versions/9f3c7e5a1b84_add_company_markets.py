@@ -0,0 +1,27 @@
+"""Add company markets
+
+Revision ID: 9f3c7e5a1b84
+Revises: 6d1f8b4a2c97
+Create Date: 2025-03-24 10:18:47.921365
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = '9f3c7e5a1b84'
+down_revision = '6d1f8b4a2c97'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'company',
+        sa.Column('markets', sa.JSON(), nullable=False, server_default='[]'),
+    )
+
+
+def downgrade():
+    op.drop_column('company', 'markets')
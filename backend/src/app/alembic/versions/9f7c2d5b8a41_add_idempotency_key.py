@@ -0,0 +1,39 @@
+"""Add idempotency key
+
+Revision ID: 9f7c2d5b8a41
+Revises: 6c4e91b7d2a8
+Create Date: 2025-02-17 10:12:37.854209
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = '9f7c2d5b8a41'
+down_revision = '6c4e91b7d2a8'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'idempotencykey',
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('key', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=False),
+        sa.Column('user_id', sa.Uuid(), nullable=False),
+        sa.Column('endpoint', sqlmodel.sql.sqltypes.AutoString(length=64), nullable=False),
+        sa.Column('response_body', sa.JSON(), nullable=True),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.ForeignKeyConstraint(['user_id'], ['user.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+    )
+    op.create_index(
+        op.f('ix_idempotencykey_key'), 'idempotencykey', ['key'], unique=False
+    )
+
+
+def downgrade():
+    op.drop_index(op.f('ix_idempotencykey_key'), table_name='idempotencykey')
+    op.drop_table('idempotencykey')
@@ -0,0 +1,28 @@
+"""Add analysis trace id
+
+Revision ID: a6f31d5c8e92
+Revises: f4c29a8e6b31
+Create Date: 2025-01-31 09:27:45.110368
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = 'a6f31d5c8e92'
+down_revision = 'f4c29a8e6b31'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'analysis',
+        sa.Column('trace_id', sqlmodel.sql.sqltypes.AutoString(length=64), nullable=True),
+    )
+
+
+def downgrade():
+    op.drop_column('analysis', 'trace_id')
@@ -0,0 +1,48 @@
+"""Add organization and user role
+
+Revision ID: b8d4e6a1f520
+Revises: 7c41f0b2d3a9
+Create Date: 2025-01-16 09:30:44.902133
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = 'b8d4e6a1f520'
+down_revision = '7c41f0b2d3a9'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'organization',
+        sa.Column('name', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=False),
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('settings', sa.JSON(), nullable=True),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.PrimaryKeyConstraint('id'),
+    )
+    op.add_column(
+        'user',
+        sa.Column(
+            'role',
+            sqlmodel.sql.sqltypes.AutoString(),
+            nullable=False,
+            server_default='analyst',
+        ),
+    )
+    op.add_column('user', sa.Column('organization_id', sa.Uuid(), nullable=True))
+    op.create_foreign_key(
+        None, 'user', 'organization', ['organization_id'], ['id'], ondelete='SET NULL'
+    )
+
+
+def downgrade():
+    op.drop_constraint(None, 'user', type_='foreignkey')
+    op.drop_column('user', 'organization_id')
+    op.drop_column('user', 'role')
+    op.drop_table('organization')
@@ -0,0 +1,38 @@
+"""Add webhooks
+
+Revision ID: c5e80d7f2b16
+Revises: 3f9a12c87e04
+Create Date: 2025-01-22 11:05:27.664410
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = 'c5e80d7f2b16'
+down_revision = '3f9a12c87e04'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'webhook',
+        sa.Column('url', sqlmodel.sql.sqltypes.AutoString(length=2000), nullable=False),
+        sa.Column('events', sa.JSON(), nullable=True),
+        sa.Column('is_active', sa.Boolean(), nullable=False),
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('organization_id', sa.Uuid(), nullable=False),
+        sa.Column('secret', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=False),
+        sa.Column('created_by', sa.Uuid(), nullable=False),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.ForeignKeyConstraint(['organization_id'], ['organization.id'], ondelete='CASCADE'),
+        sa.ForeignKeyConstraint(['created_by'], ['user.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+    )
+
+
+def downgrade():
+    op.drop_table('webhook')
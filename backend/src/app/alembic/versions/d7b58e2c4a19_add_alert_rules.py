@@ -0,0 +1,37 @@
+"""Add alert rules
+
+Revision ID: d7b58e2c4a19
+Revises: a6f31d5c8e92
+Create Date: 2025-02-03 11:54:08.772459
+
+"""
+from alembic import op
+import sqlalchemy as sa
+import sqlmodel.sql.sqltypes
+
+
+# revision identifiers, used by Alembic.
+revision = 'd7b58e2c4a19'
+down_revision = 'a6f31d5c8e92'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.create_table(
+        'alertrule',
+        sa.Column('name', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=False),
+        sa.Column('min_score', sa.Float(), nullable=True),
+        sa.Column('risk_category', sqlmodel.sql.sqltypes.AutoString(length=255), nullable=True),
+        sa.Column('channels', sa.JSON(), nullable=True),
+        sa.Column('is_active', sa.Boolean(), nullable=False),
+        sa.Column('id', sa.Uuid(), nullable=False),
+        sa.Column('user_id', sa.Uuid(), nullable=False),
+        sa.Column('created_at', sa.DateTime(), nullable=False),
+        sa.ForeignKeyConstraint(['user_id'], ['user.id'], ondelete='CASCADE'),
+        sa.PrimaryKeyConstraint('id'),
+    )
+
+
+def downgrade():
+    op.drop_table('alertrule')
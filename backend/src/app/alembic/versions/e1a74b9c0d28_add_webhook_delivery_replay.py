@@ -0,0 +1,28 @@
+"""Add webhook delivery replay
+
+Revision ID: e1a74b9c0d28
+Revises: 9d2b6c3e14f7
+Create Date: 2025-01-27 10:02:33.481220
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = 'e1a74b9c0d28'
+down_revision = '9d2b6c3e14f7'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column('webhookdelivery', sa.Column('replay_of', sa.Uuid(), nullable=True))
+    op.create_foreign_key(
+        None, 'webhookdelivery', 'webhookdelivery', ['replay_of'], ['id']
+    )
+
+
+def downgrade():
+    op.drop_constraint(None, 'webhookdelivery', type_='foreignkey')
+    op.drop_column('webhookdelivery', 'replay_of')
@@ -0,0 +1,32 @@
+"""Add analysis notification preference
+
+Revision ID: f4c29a8e6b31
+Revises: e1a74b9c0d28
+Create Date: 2025-01-29 16:44:09.557182
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = 'f4c29a8e6b31'
+down_revision = 'e1a74b9c0d28'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.add_column(
+        'user',
+        sa.Column(
+            'notify_on_analysis',
+            sa.Boolean(),
+            nullable=False,
+            server_default=sa.true(),
+        ),
+    )
+
+
+def downgrade():
+    op.drop_column('user', 'notify_on_analysis')
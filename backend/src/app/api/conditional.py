@@ -0,0 +1,28 @@
+import hashlib
+import json
+from typing import Any
+
+from fastapi import HTTPException, Request
+from fastapi.encoders import jsonable_encoder
+
+
+def compute_etag(model: Any) -> str:
+    """Strong ETag over a resource's public representation."""
+    payload = json.dumps(jsonable_encoder(model), sort_keys=True)
+    return f'"{hashlib.sha256(payload.encode()).hexdigest()[:32]}"'
+
+
+def is_not_modified(request: Request, etag: str) -> bool:
+    return request.headers.get("If-None-Match") == etag
+
+
+def require_if_match(request: Request, etag: str) -> None:
+    """Guard updates against lost writes: the client must prove it saw the
+    current version of the resource."""
+    value = request.headers.get("If-Match")
+    if value is None:
+        raise HTTPException(status_code=428, detail="If-Match header required")
+    if value != etag:
+        raise HTTPException(
+            status_code=412, detail="Resource was modified by someone else"
+        )
@@ -0,0 +1,75 @@
+"""Per-route-group CORS policies.
+
+Starlette's ``CORSMiddleware`` applies one policy to the whole app, but
+the public share endpoints and the authenticated API need different
+rules: signed download links are meant to be fetched from anywhere and
+carry no credentials, while the rest of the API only trusts the
+configured frontend origins and allows credentials. Policies resolve by
+path prefix, preflights are answered directly, and responses carry
+``Access-Control-Max-Age`` so browsers cache the preflight.
+"""
+
+from dataclasses import dataclass, field
+
+from fastapi import Request, Response
+
+from app.core.config import settings
+
+# How long browsers may cache a preflight response
+MAX_AGE_SECONDS = 600
+
+ALLOW_METHODS = "DELETE, GET, HEAD, OPTIONS, PATCH, POST, PUT"
+
+
+@dataclass
+class CorsPolicy:
+    allow_origins: list[str] = field(default_factory=list)
+    allow_credentials: bool = False
+
+
+def policy_for(path: str) -> CorsPolicy | None:
+    """The policy governing a path; None when CORS is closed entirely."""
+    if path.startswith(f"{settings.API_V1_STR}/downloads"):
+        # Signed links gate access themselves; any origin may fetch them
+        return CorsPolicy(allow_origins=["*"])
+    if settings.all_cors_origins:
+        return CorsPolicy(
+            allow_origins=settings.all_cors_origins, allow_credentials=True
+        )
+    return None
+
+
+def response_headers(
+    policy: CorsPolicy, origin: str
+) -> dict[str, str] | None:
+    """CORS headers for an allowed origin, None when it is not allowed."""
+    wildcard = "*" in policy.allow_origins
+    if not wildcard and origin not in policy.allow_origins:
+        return None
+    headers = {
+        # Credentialed responses may not use the wildcard form
+        "Access-Control-Allow-Origin": (
+            "*" if wildcard and not policy.allow_credentials else origin
+        ),
+        "Vary": "Origin",
+    }
+    if policy.allow_credentials:
+        headers["Access-Control-Allow-Credentials"] = "true"
+    return headers
+
+
+def preflight_response(request: Request, policy: CorsPolicy) -> Response:
+    origin = request.headers.get("origin", "")
+    headers = response_headers(policy, origin)
+    if headers is None:
+        return Response(status_code=400, content="Disallowed CORS origin")
+    headers.update(
+        {
+            "Access-Control-Allow-Methods": ALLOW_METHODS,
+            "Access-Control-Allow-Headers": request.headers.get(
+                "access-control-request-headers", "*"
+            ),
+            "Access-Control-Max-Age": str(MAX_AGE_SECONDS),
+        }
+    )
+    return Response(status_code=200, headers=headers)
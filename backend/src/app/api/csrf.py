@@ -0,0 +1,40 @@
+"""CSRF protection for cookie-authenticated requests.
+
+The API itself is bearer-token only, and a cross-site page cannot attach
+an Authorization header, so those requests need no CSRF defense.
+Deployments that front the API with cookie sessions (an auth proxy, or a
+gateway that maps a session cookie onto a bearer token) are exploitable
+via cross-site form posts, so state-changing requests arriving with
+cookies but no bearer token must double-submit a CSRF token: the value
+of the ``csrftoken`` cookie echoed in the ``X-CSRF-Token`` header. The
+token is issued by ``GET /utils/csrf-token/``.
+"""
+
+import hmac
+import secrets
+
+from fastapi import Request
+
+CSRF_COOKIE = "csrftoken"
+CSRF_HEADER = "X-CSRF-Token"
+
+SAFE_METHODS = {"GET", "HEAD", "OPTIONS"}
+
+
+def new_token() -> str:
+    return secrets.token_urlsafe(32)
+
+
+def check_request(request: Request) -> bool:
+    """True when the request may proceed."""
+    if request.method in SAFE_METHODS:
+        return True
+    if request.headers.get("Authorization", "").lower().startswith("bearer "):
+        return True
+    if not request.cookies:
+        return True
+    cookie = request.cookies.get(CSRF_COOKIE)
+    header = request.headers.get(CSRF_HEADER)
+    return bool(
+        cookie and header and hmac.compare_digest(cookie, header)
+    )
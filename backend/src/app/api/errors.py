@@ -0,0 +1,67 @@
+"""Stable machine-readable error codes.
+
+Error details are English prose tuned for humans; SDKs and the frontend
+should branch on stable codes instead of parsing strings. Codes derive
+from the detail message through this registry and ride along on every
+error response as ``code``; messages not registered here fall back to a
+generic code for their status. Renaming a code is a breaking API change.
+"""
+
+from typing import Any
+
+# Exact detail message -> code
+ERROR_CODES = {
+    "Company not found": "COMPANY_NOT_FOUND",
+    "Analysis not found": "ANALYSIS_NOT_FOUND",
+    "Upload not found": "UPLOAD_NOT_FOUND",
+    "Page not found": "PAGE_NOT_FOUND",
+    "User not found": "USER_NOT_FOUND",
+    "Organization not found": "ORGANIZATION_NOT_FOUND",
+    "Not enough permissions": "NOT_ENOUGH_PERMISSIONS",
+    "The user doesn't have enough privileges": "NOT_ENOUGH_PERMISSIONS",
+    "Incorrect email or password": "INCORRECT_CREDENTIALS",
+    "Could not validate credentials": "INVALID_TOKEN",
+    "Inactive user": "INACTIVE_USER",
+    "Analysis is not completed": "ANALYSIS_NOT_READY",
+    "Analysis is already in review": "ANALYSIS_IN_REVIEW",
+    "File is quarantined": "FILE_QUARANTINED",
+    "File is too large": "FILE_TOO_LARGE",
+    "Request body is too large": "FILE_TOO_LARGE",
+    "Rate limit exceeded": "RATE_LIMITED",
+    "Source IP is not allowlisted": "IP_NOT_ALLOWLISTED",
+    "CSRF token missing or invalid": "CSRF_INVALID",
+    "Invalid or expired download link": "DOWNLOAD_LINK_INVALID",
+}
+
+# Prefix of a parameterized detail message -> code
+PREFIX_CODES = [
+    ("File content (", "FILE_TYPE_NOT_ALLOWED"),
+    ("Invalid CIDR range", "INVALID_CIDR"),
+    ("Unknown metric", "UNKNOWN_METRIC"),
+]
+
+# Fallbacks keyed by status when the message is not registered
+GENERIC_CODES = {
+    400: "BAD_REQUEST",
+    401: "UNAUTHORIZED",
+    403: "FORBIDDEN",
+    404: "NOT_FOUND",
+    409: "CONFLICT",
+    413: "PAYLOAD_TOO_LARGE",
+    415: "UNSUPPORTED_MEDIA_TYPE",
+    422: "VALIDATION_ERROR",
+    429: "RATE_LIMITED",
+    500: "INTERNAL_ERROR",
+}
+
+VALIDATION_ERROR_CODE = "VALIDATION_ERROR"
+
+
+def code_for(status_code: int, detail: Any) -> str:
+    if isinstance(detail, str):
+        if detail in ERROR_CODES:
+            return ERROR_CODES[detail]
+        for prefix, code in PREFIX_CODES:
+            if detail.startswith(prefix):
+                return code
+    return GENERIC_CODES.get(status_code, "ERROR")
@@ -0,0 +1,46 @@
+import uuid
+from typing import Any
+
+from fastapi import Request
+from fastapi.encoders import jsonable_encoder
+from fastapi.responses import JSONResponse
+from sqlmodel import Session, select
+
+from app.models import IdempotencyKey
+
+HEADER = "Idempotency-Key"
+
+
+def request_key(request: Request) -> str | None:
+    return request.headers.get(HEADER)
+
+
+def cached_response(
+    session: Session, user_id: uuid.UUID, endpoint: str, key: str
+) -> JSONResponse | None:
+    """Return the stored response for a replayed key, if any."""
+    statement = select(IdempotencyKey).where(
+        IdempotencyKey.user_id == user_id,
+        IdempotencyKey.endpoint == endpoint,
+        IdempotencyKey.key == key,
+    )
+    record = session.exec(statement).first()
+    if record is None:
+        return None
+    return JSONResponse(
+        content=record.response_body, headers={"Idempotency-Replayed": "true"}
+    )
+
+
+def store_response(
+    session: Session, user_id: uuid.UUID, endpoint: str, key: str, body: Any
+) -> None:
+    """Remember the response so a retry with the same key replays it."""
+    record = IdempotencyKey(
+        key=key,
+        user_id=user_id,
+        endpoint=endpoint,
+        response_body=jsonable_encoder(body),
+    )
+    session.add(record)
+    session.commit()
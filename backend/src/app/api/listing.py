@@ -0,0 +1,77 @@
+import base64
+import binascii
+import json
+import uuid
+from collections.abc import Sequence
+from datetime import datetime
+from typing import Any
+
+from fastapi import HTTPException
+from sqlmodel import SQLModel, col
+
+
+def parse_sort(
+    sort: str | None, sortable: dict[str, Any], default: list[Any]
+) -> list[Any]:
+    """Turn ``?sort=overall_score,-created_at`` into ORDER BY clauses.
+
+    ``sortable`` whitelists the exposed names against model columns; anything
+    else is rejected rather than leaking column names into the API.
+    """
+    if not sort:
+        return default
+    clauses = []
+    for part in sort.split(","):
+        name = part.strip()
+        descending = name.startswith("-")
+        if descending:
+            name = name[1:]
+        if name not in sortable:
+            raise HTTPException(status_code=422, detail=f"Cannot sort by {name!r}")
+        column = col(sortable[name])
+        clauses.append(column.desc() if descending else column.asc())
+    return clauses
+
+
+def encode_cursor(created_at: datetime, id: uuid.UUID) -> str:
+    """Pack a keyset position into an opaque, URL-safe cursor."""
+    raw = json.dumps([created_at.isoformat(), str(id)]).encode()
+    return base64.urlsafe_b64encode(raw).decode()
+
+
+def decode_cursor(cursor: str) -> tuple[datetime, uuid.UUID]:
+    try:
+        created_at, id = json.loads(base64.urlsafe_b64decode(cursor.encode()))
+        return datetime.fromisoformat(created_at), uuid.UUID(id)
+    except (ValueError, TypeError, binascii.Error):
+        raise HTTPException(status_code=422, detail="Invalid cursor")
+
+
+def parse_ids(ids: str) -> list[uuid.UUID]:
+    """Parse ``?ids=a,b,c`` into UUIDs, rejecting malformed entries."""
+    parsed = []
+    for part in ids.split(","):
+        try:
+            parsed.append(uuid.UUID(part.strip()))
+        except ValueError:
+            raise HTTPException(
+                status_code=422, detail=f"Invalid ID: {part.strip()!r}"
+            )
+    return parsed
+
+
+def select_fields(
+    items: Sequence[Any], fields: str, public: type[SQLModel]
+) -> list[dict[str, Any]]:
+    """Project items onto the subset of ``public``'s fields named in
+    ``?fields=id,name,...`` so heavy columns stay out of the payload."""
+    names = [name.strip() for name in fields.split(",") if name.strip()]
+    unknown = [name for name in names if name not in public.model_fields]
+    if unknown:
+        raise HTTPException(
+            status_code=422, detail=f"Unknown fields: {', '.join(unknown)}"
+        )
+    include = set(names)
+    return [
+        public.model_validate(item).model_dump(include=include) for item in items
+    ]
@@ -1,6 +1,36 @@
 from fastapi import APIRouter
 
-from app.api.routes import items, login, private, users, utils
+from app.api.routes import (
+    alerts,
+    analysis,
+    analysts,
+    analytics,
+    benchmarks,
+    bootstrap,
+    companies,
+    downloads,
+    exits,
+    founders,
+    funding_rounds,
+    items,
+    login,
+    organizations,
+    pipeline,
+    portfolios,
+    private,
+    report_templates,
+    schedules,
+    scoring_models,
+    search,
+    tags,
+    tools,
+    uploads,
+    users,
+    utils,
+    vector_index,
+    webhooks,
+    ws,
+)
 from app.core.config import settings
 
 api_router = APIRouter()
@@ -8,6 +38,30 @@ api_router.include_router(login.router)
 api_router.include_router(users.router)
 api_router.include_router(utils.router)
 api_router.include_router(items.router)
+api_router.include_router(companies.router)
+api_router.include_router(analysis.router)
+api_router.include_router(bootstrap.router)
+api_router.include_router(ws.router)
+api_router.include_router(webhooks.router)
+api_router.include_router(organizations.router)
+api_router.include_router(alerts.router)
+api_router.include_router(vector_index.router)
+api_router.include_router(uploads.router)
+api_router.include_router(report_templates.router)
+api_router.include_router(downloads.router)
+api_router.include_router(search.router)
+api_router.include_router(pipeline.router)
+api_router.include_router(tags.router)
+api_router.include_router(funding_rounds.router)
+api_router.include_router(founders.router)
+api_router.include_router(tools.router)
+api_router.include_router(benchmarks.router)
+api_router.include_router(portfolios.router)
+api_router.include_router(schedules.router)
+api_router.include_router(analytics.router)
+api_router.include_router(exits.router)
+api_router.include_router(scoring_models.router)
+api_router.include_router(analysts.router)
 
 
 if settings.ENVIRONMENT == "local":
@@ -0,0 +1,48 @@
+"""RFC 7807 problem detail error responses.
+
+Clients that send ``Accept: application/problem+json`` get errors in the
+RFC 7807 shape — type, title, status, detail, instance — while everyone
+else keeps the flat ``{"detail": ...}`` format the frontend already
+parses. Negotiation is per request, so SDKs can opt in without a
+breaking change.
+"""
+
+from http import HTTPStatus
+from typing import Any
+
+from fastapi import Request
+from fastapi.responses import JSONResponse
+
+PROBLEM_CONTENT_TYPE = "application/problem+json"
+
+
+def wants_problem_json(request: Request) -> bool:
+    return PROBLEM_CONTENT_TYPE in request.headers.get("accept", "")
+
+
+def problem_response(
+    request: Request,
+    status_code: int,
+    detail: Any,
+    headers: dict[str, str] | None = None,
+    **extensions: Any,
+) -> JSONResponse:
+    try:
+        title = HTTPStatus(status_code).phrase
+    except ValueError:
+        title = "Error"
+    body: dict[str, Any] = {
+        # "about:blank" is the RFC 7807 type for plain HTTP semantics
+        "type": "about:blank",
+        "title": title,
+        "status": status_code,
+        "detail": detail,
+        "instance": request.url.path,
+    }
+    body.update(extensions)
+    return JSONResponse(
+        status_code=status_code,
+        content=body,
+        headers=headers,
+        media_type=PROBLEM_CONTENT_TYPE,
+    )
@@ -0,0 +1,80 @@
+"""Fixed-window rate limiting with standard response headers.
+
+Every response carries ``X-RateLimit-Limit/Remaining/Reset`` so SDKs can
+pace themselves, and throttled requests get a 429 with ``Retry-After``.
+Counters are process-local by design: multi-worker deployments get a
+per-worker budget, which errs on the permissive side rather than adding
+a shared store to the hot path. Buckets from finished windows are swept
+once per window so the counter table stays bounded.
+"""
+
+import time
+
+import jwt
+from fastapi import Request
+from jwt.exceptions import InvalidTokenError
+
+from app.core import security
+from app.core.config import settings
+
+WINDOW_SECONDS = 60
+
+# client key -> (window start epoch, request count)
+_counters: dict[str, tuple[int, int]] = {}
+
+# Window whose stale buckets were last swept
+_swept_window = 0
+
+
+def client_key(request: Request) -> str:
+    # A valid bearer token identifies the caller across addresses. The
+    # signature is verified first: a forged token must not mint a fresh
+    # bucket, so anything unverifiable counts against the client IP.
+    authorization = request.headers.get("Authorization", "")
+    if authorization.lower().startswith("bearer "):
+        token = authorization.split(" ", 1)[1]
+        try:
+            payload = jwt.decode(
+                token, settings.SECRET_KEY, algorithms=[security.ALGORITHM]
+            )
+        except InvalidTokenError:
+            pass
+        else:
+            return f"user:{payload.get('sub')}"
+    return f"ip:{request.client.host}" if request.client else "anonymous"
+
+
+def _sweep(window: int) -> None:
+    global _swept_window
+    if window == _swept_window:
+        return
+    _swept_window = window
+    for key in [k for k, (start, _) in _counters.items() if start != window]:
+        del _counters[key]
+
+
+def check(request: Request) -> tuple[bool, dict[str, str]]:
+    """Count this request; returns whether it may proceed, plus the rate
+    limit headers the response should carry."""
+    limit = settings.RATE_LIMIT_PER_MINUTE
+    if limit <= 0:
+        return True, {}
+    now = int(time.time())
+    window = now - now % WINDOW_SECONDS
+    _sweep(window)
+    key = client_key(request)
+    start, count = _counters.get(key, (window, 0))
+    if start != window:
+        start, count = window, 0
+    count += 1
+    _counters[key] = (start, count)
+    reset = start + WINDOW_SECONDS
+    headers = {
+        "X-RateLimit-Limit": str(limit),
+        "X-RateLimit-Remaining": str(max(0, limit - count)),
+        "X-RateLimit-Reset": str(reset),
+    }
+    if count > limit:
+        headers["Retry-After"] = str(max(1, reset - now))
+        return False, headers
+    return True, headers
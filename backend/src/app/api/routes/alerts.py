@@ -0,0 +1,108 @@
+import uuid
+from typing import Any
+
+from fastapi import APIRouter, HTTPException
+from sqlmodel import func, select
+
+from app.api.deps import CurrentUser, SessionDep
+from app.models import (
+    ALERT_CHANNELS,
+    AlertRule,
+    AlertRuleCreate,
+    AlertRulePublic,
+    AlertRulesPublic,
+    AlertRuleUpdate,
+    Message,
+)
+
+router = APIRouter(prefix="/alerts", tags=["alerts"])
+
+
+def _validate_channels(channels: list[str]) -> None:
+    unknown = [c for c in channels if c not in ALERT_CHANNELS]
+    if unknown:
+        raise HTTPException(
+            status_code=422, detail=f"Unknown channels: {', '.join(unknown)}"
+        )
+
+
+def get_rule_for_user(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> AlertRule:
+    rule = session.get(AlertRule, id)
+    if not rule:
+        raise HTTPException(status_code=404, detail="Alert rule not found")
+    if not current_user.is_superuser and (rule.user_id != current_user.id):
+        raise HTTPException(status_code=400, detail="Not enough permissions")
+    return rule
+
+
+@router.get("/", response_model=AlertRulesPublic)
+def read_alert_rules(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Retrieve the caller's alert rules.
+    """
+    count_statement = (
+        select(func.count())
+        .select_from(AlertRule)
+        .where(AlertRule.user_id == current_user.id)
+    )
+    count = session.exec(count_statement).one()
+    statement = select(AlertRule).where(AlertRule.user_id == current_user.id)
+    rules = session.exec(statement).all()
+    return AlertRulesPublic(data=rules, count=count)
+
+
+@router.post("/", response_model=AlertRulePublic)
+def create_alert_rule(
+    *, session: SessionDep, current_user: CurrentUser, rule_in: AlertRuleCreate
+) -> Any:
+    """
+    Create an alert rule evaluated when analyses complete.
+    """
+    if rule_in.min_score is None and rule_in.risk_category is None:
+        raise HTTPException(
+            status_code=422,
+            detail="Rule needs a score threshold or a risk category",
+        )
+    _validate_channels(rule_in.channels)
+    rule = AlertRule.model_validate(rule_in, update={"user_id": current_user.id})
+    session.add(rule)
+    session.commit()
+    session.refresh(rule)
+    return rule
+
+
+@router.patch("/{id}", response_model=AlertRulePublic)
+def update_alert_rule(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    rule_in: AlertRuleUpdate,
+) -> Any:
+    """
+    Update an alert rule.
+    """
+    rule = get_rule_for_user(session, current_user, id)
+    update_dict = rule_in.model_dump(exclude_unset=True)
+    if "channels" in update_dict:
+        _validate_channels(update_dict["channels"])
+    rule.sqlmodel_update(update_dict)
+    session.add(rule)
+    session.commit()
+    session.refresh(rule)
+    return rule
+
+
+@router.delete("/{id}")
+def delete_alert_rule(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Message:
+    """
+    Delete an alert rule.
+    """
+    rule = get_rule_for_user(session, current_user, id)
+    session.delete(rule)
+    session.commit()
+    return Message(message="Alert rule deleted successfully")
@@ -0,0 +1,1076 @@
+import asyncio
+import json
+import re
+import uuid
+from collections.abc import AsyncGenerator
+from datetime import datetime
+from typing import Any
+
+from fastapi import (
+    APIRouter,
+    BackgroundTasks,
+    Depends,
+    HTTPException,
+    Request,
+    Response,
+)
+from fastapi.encoders import jsonable_encoder
+from fastapi.responses import JSONResponse, StreamingResponse
+from sqlmodel import and_, col, func, or_, select
+
+from app.api import idempotency
+from app.api.conditional import compute_etag, is_not_modified
+from app.api.deps import CurrentUser, SessionDep, get_current_active_superuser
+from app.api.listing import (
+    decode_cursor,
+    encode_cursor,
+    parse_ids,
+    parse_sort,
+    select_fields,
+)
+from app.core import tracing
+from app.models import (
+    AnalysesPublic,
+    Analysis,
+    AnalysisComparisonColumn,
+    AnalysisComparisonPublic,
+    AnalysisCreate,
+    AnalysisDiffPublic,
+    AnalysisPublic,
+    AnalysisStatus,
+    AnalysisStatusPublic,
+    AnalysisTag,
+    AssigneeUpdate,
+    Company,
+    DownloadLinkPublic,
+    Message,
+    MetricDelta,
+    NotionPagePublic,
+    Organization,
+    QueuePause,
+    QueuePauseRequest,
+    QueueStatePublic,
+    ReviewDecision,
+    ReviewState,
+    ReviewSubmit,
+    ScoreOverrideRequest,
+    Tag,
+    UnitEconomicsInput,
+    UploadedFile,
+    User,
+    utcnow,
+)
+from app.services import audit, financials, notion, queue
+from app.services.analysis import (
+    apply_score_overrides,
+    is_stale,
+    next_version,
+    run_analysis,
+)
+from app.services.downloads import generate_download_token
+from app.services.events import broker
+from app.services.exports import (
+    analysis_recommendation,
+    build_analyses_csv,
+    build_portfolio_xlsx,
+)
+from app.services.i18n import SUPPORTED_LOCALES
+from app.services.reports import (
+    build_docx_report,
+    build_html_report,
+    build_pdf_report,
+    get_active_template,
+    get_branding,
+)
+
+router = APIRouter(prefix="/analysis", tags=["analysis"])
+
+# Keep-alive interval for SSE streams; comments keep proxies from closing
+# otherwise idle connections.
+SSE_KEEPALIVE_SECONDS = 15
+
+ANALYSIS_SORTABLE = {
+    "created_at": Analysis.created_at,
+    "completed_at": Analysis.completed_at,
+    "status": Analysis.status,
+    "score": Analysis.score,
+    # Alias matching the name the dashboard displays
+    "overall_score": Analysis.score,
+}
+
+
+def get_analysis_for_user(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Analysis:
+    analysis = session.get(Analysis, id)
+    if not analysis:
+        raise HTTPException(status_code=404, detail="Analysis not found")
+    company = session.get(Company, analysis.company_id)
+    if company and company.deleted_at is not None:
+        # Soft-deleted companies hide their analyses too
+        raise HTTPException(status_code=404, detail="Analysis not found")
+    if not current_user.is_superuser and (
+        not company or company.owner_id != current_user.id
+    ):
+        raise HTTPException(status_code=400, detail="Not enough permissions")
+    return analysis
+
+
+@router.get("/", response_model=AnalysesPublic)
+def read_analyses(
+    session: SessionDep,
+    current_user: CurrentUser,
+    company_id: uuid.UUID | None = None,
+    status: AnalysisStatus | None = None,
+    min_score: float | None = None,
+    max_score: float | None = None,
+    created_after: datetime | None = None,
+    created_before: datetime | None = None,
+    page: int = 1,
+    per_page: int = 50,
+    sort: str | None = None,
+    fields: str | None = None,
+    ids: str | None = None,
+    cursor: str | None = None,
+    tag: str | None = None,
+) -> Any:
+    """
+    Retrieve analyses, newest first by default, filterable by company,
+    status, score range, and creation date range. ``sort`` takes
+    comma-separated fields, ``-`` prefix for descending; ``fields`` limits
+    each row to the named fields; ``ids`` bulk-fetches specific analyses;
+    ``tag`` keeps only analyses carrying one of the caller's tags by name.
+
+    Pass ``cursor=`` (empty for the first page) to page by keyset instead of
+    offset; follow ``next_cursor`` until it is null.
+    """
+    if page < 1 or per_page < 1 or per_page > 100:
+        raise HTTPException(status_code=422, detail="Invalid pagination parameters")
+    filters = []
+    if ids is not None:
+        filters.append(col(Analysis.id).in_(parse_ids(ids)))
+    if company_id is not None:
+        filters.append(Analysis.company_id == company_id)
+    if status is not None:
+        filters.append(Analysis.status == status)
+    if min_score is not None:
+        filters.append(col(Analysis.score) >= min_score)
+    if max_score is not None:
+        filters.append(col(Analysis.score) <= max_score)
+    if created_after is not None:
+        filters.append(col(Analysis.created_at) >= created_after)
+    if created_before is not None:
+        filters.append(col(Analysis.created_at) <= created_before)
+    if tag is not None:
+        filters.append(
+            col(Analysis.id).in_(
+                select(AnalysisTag.analysis_id)
+                .join(Tag)
+                .where(Tag.name == tag, Tag.owner_id == current_user.id)
+            )
+        )
+    count_statement = (
+        select(func.count())
+        .select_from(Analysis)
+        .join(Company)
+        .where(col(Company.deleted_at).is_(None), *filters)
+    )
+    statement = (
+        select(Analysis)
+        .join(Company)
+        .where(col(Company.deleted_at).is_(None), *filters)
+    )
+    if not current_user.is_superuser:
+        count_statement = count_statement.where(
+            Company.owner_id == current_user.id
+        )
+        statement = statement.where(Company.owner_id == current_user.id)
+    count = session.exec(count_statement).one()
+    next_cursor = None
+    if cursor is not None:
+        if sort is not None:
+            raise HTTPException(
+                status_code=422, detail="Cursor pagination uses a fixed order"
+            )
+        if cursor:
+            created_at, last_id = decode_cursor(cursor)
+            statement = statement.where(
+                or_(
+                    col(Analysis.created_at) < created_at,
+                    and_(
+                        col(Analysis.created_at) == created_at,
+                        col(Analysis.id) < last_id,
+                    ),
+                )
+            )
+        analyses = session.exec(
+            statement.order_by(
+                col(Analysis.created_at).desc(), col(Analysis.id).desc()
+            ).limit(per_page)
+        ).all()
+        if len(analyses) == per_page:
+            next_cursor = encode_cursor(analyses[-1].created_at, analyses[-1].id)
+    else:
+        order_by = parse_sort(
+            sort, ANALYSIS_SORTABLE, [col(Analysis.created_at).desc()]
+        )
+        analyses = session.exec(
+            statement.order_by(*order_by)
+            .offset((page - 1) * per_page)
+            .limit(per_page)
+        ).all()
+    if fields is not None:
+        return JSONResponse(
+            content=jsonable_encoder(
+                {
+                    "data": select_fields(analyses, fields, AnalysisPublic),
+                    "count": count,
+                    "next_cursor": next_cursor,
+                }
+            )
+        )
+    return AnalysesPublic(data=analyses, count=count, next_cursor=next_cursor)
+
+
+@router.post("/", response_model=AnalysisPublic)
+def create_analysis(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    analysis_in: AnalysisCreate,
+    background_tasks: BackgroundTasks,
+    request: Request,
+) -> Any:
+    """
+    Start a new analysis for a company. Retries carrying the same
+    ``Idempotency-Key`` header replay the first response instead of
+    double-starting the analysis.
+    """
+    key = idempotency.request_key(request)
+    if key:
+        cached = idempotency.cached_response(
+            session, current_user.id, "create_analysis", key
+        )
+        if cached:
+            return cached
+    company = session.get(Company, analysis_in.company_id)
+    if not company:
+        raise HTTPException(status_code=404, detail="Company not found")
+    if not current_user.is_superuser and (company.owner_id != current_user.id):
+        raise HTTPException(status_code=400, detail="Not enough permissions")
+    for file_id in analysis_in.file_ids:
+        upload = session.get(UploadedFile, file_id)
+        if not upload or upload.company_id != company.id:
+            raise HTTPException(
+                status_code=422,
+                detail=f"File {file_id} does not belong to this company",
+            )
+    if any(weight < 0 for weight in analysis_in.weights.values()):
+        raise HTTPException(
+            status_code=422, detail="Weights must not be negative"
+        )
+    version, supersedes_id = next_version(session, company.id)
+    analysis = Analysis(
+        company_id=company.id,
+        requested_by=current_user.id,
+        trace_id=tracing.get_request_id(),
+        version=version,
+        supersedes_id=supersedes_id,
+        # Stored up front so the scoring stage can apply the per-run rubric
+        results={"rubric": analysis_in.weights} if analysis_in.weights else {},
+    )
+    session.add(analysis)
+    session.commit()
+    session.refresh(analysis)
+    background_tasks.add_task(run_analysis, analysis.id)
+    if key:
+        idempotency.store_response(
+            session,
+            current_user.id,
+            "create_analysis",
+            key,
+            AnalysisPublic.model_validate(analysis),
+        )
+    return analysis
+
+
+# Queue routes are declared before /{id} so "queue" is never parsed as an ID.
+@router.get(
+    "/queue",
+    dependencies=[Depends(get_current_active_superuser)],
+    response_model=QueueStatePublic,
+)
+def read_queue_state(session: SessionDep) -> Any:
+    """
+    Get the pause state of the analysis queue.
+    """
+    pauses = session.exec(select(QueuePause)).all()
+    return QueueStatePublic(
+        paused_globally=any(p.organization_id is None for p in pauses),
+        paused_organizations=[
+            p.organization_id for p in pauses if p.organization_id is not None
+        ],
+    )
+
+
+@router.post(
+    "/queue/pause",
+    dependencies=[Depends(get_current_active_superuser)],
+    response_model=Message,
+)
+def pause_queue(
+    session: SessionDep, current_user: CurrentUser, request: QueuePauseRequest
+) -> Any:
+    """
+    Pause job consumption, globally or for one organization.
+    """
+    statement = select(QueuePause).where(
+        col(QueuePause.organization_id).is_(None)
+        if request.organization_id is None
+        else QueuePause.organization_id == request.organization_id
+    )
+    if session.exec(statement).first():
+        return Message(message="Queue already paused")
+    pause = QueuePause(
+        organization_id=request.organization_id, paused_by=current_user.id
+    )
+    session.add(pause)
+    session.commit()
+    return Message(message="Queue paused")
+
+
+@router.post(
+    "/queue/resume",
+    dependencies=[Depends(get_current_active_superuser)],
+    response_model=Message,
+)
+def resume_queue(
+    session: SessionDep,
+    request: QueuePauseRequest,
+    background_tasks: BackgroundTasks,
+) -> Any:
+    """
+    Resume job consumption and re-dispatch analyses left pending.
+    """
+    statement = select(QueuePause).where(
+        col(QueuePause.organization_id).is_(None)
+        if request.organization_id is None
+        else QueuePause.organization_id == request.organization_id
+    )
+    pause = session.exec(statement).first()
+    if not pause:
+        raise HTTPException(status_code=404, detail="Queue is not paused")
+    session.delete(pause)
+    session.commit()
+    for analysis in queue.pending_analyses(session, request.organization_id):
+        background_tasks.add_task(run_analysis, analysis.id)
+    return Message(message="Queue resumed")
+
+
+EXPORT_FORMATS = ("csv", "xlsx")
+
+
+def company_scope(current_user: CurrentUser) -> Any:
+    """Companies visible to the caller: their own, plus the rest of their
+    organization's for the portfolio export. Superusers see everything."""
+    if current_user.is_superuser:
+        return None
+    if current_user.organization_id is not None:
+        return col(Company.owner_id).in_(
+            select(User.id).where(User.organization_id == current_user.organization_id)
+        )
+    return Company.owner_id == current_user.id
+
+
+@router.get("/export")
+def export_analyses(
+    session: SessionDep, current_user: CurrentUser, format: str = "csv"
+) -> Response:
+    """
+    Export completed analyses for offline spreadsheet work.
+
+    ``csv`` is one flat row per analysis: company, date, overall score,
+    per-metric scores, and recommendation. ``xlsx`` is a multi-sheet
+    portfolio workbook (companies, analyses, metrics, risks).
+    """
+    if format not in EXPORT_FORMATS:
+        raise HTTPException(status_code=422, detail="Unknown export format")
+    scope = company_scope(current_user)
+    statement = (
+        select(Analysis, Company)
+        .join(Company)
+        .where(Analysis.status == AnalysisStatus.COMPLETED)
+    )
+    if scope is not None:
+        statement = statement.where(scope)
+    rows = list(session.exec(statement).all())
+    if format == "xlsx":
+        companies_statement = select(Company)
+        if scope is not None:
+            companies_statement = companies_statement.where(scope)
+        companies = list(session.exec(companies_statement).all())
+        content: bytes = build_portfolio_xlsx(companies, rows)
+        media_type = (
+            "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+        )
+        filename = "portfolio.xlsx"
+    else:
+        content = build_analyses_csv(rows)
+        media_type = "text/csv"
+        filename = "analyses.csv"
+    return Response(
+        content=content,
+        media_type=media_type,
+        headers={"Content-Disposition": f'attachment; filename="{filename}"'},
+    )
+
+
+@router.get("/compare", response_model=AnalysisComparisonPublic)
+def compare_analyses(
+    session: SessionDep, current_user: CurrentUser, ids: str
+) -> Any:
+    """
+    Compare 2-5 completed analyses side by side: per-metric scores aligned
+    on a shared metric list, plus each analysis's insights and risks.
+    """
+    analysis_ids = parse_ids(ids)
+    if not 2 <= len(analysis_ids) <= 5:
+        raise HTTPException(
+            status_code=422, detail="Provide between 2 and 5 analysis IDs"
+        )
+    analyses = [
+        get_analysis_for_user(session, current_user, analysis_id)
+        for analysis_id in analysis_ids
+    ]
+    for analysis in analyses:
+        if analysis.status != AnalysisStatus.COMPLETED:
+            raise HTTPException(
+                status_code=409, detail=f"Analysis {analysis.id} is not completed"
+            )
+    metrics: list[str] = []
+    for analysis in analyses:
+        for metric in analysis.results.get("scores", {}):
+            if metric != "overall" and metric not in metrics:
+                metrics.append(metric)
+    columns = []
+    for analysis in analyses:
+        company = session.get(Company, analysis.company_id)
+        scores = analysis.results.get("scores", {})
+        columns.append(
+            AnalysisComparisonColumn(
+                analysis_id=analysis.id,
+                company_id=analysis.company_id,
+                company_name=company.name if company else "",
+                completed_at=analysis.completed_at,
+                overall_score=analysis.score,
+                recommendation=analysis_recommendation(analysis),
+                scores={metric: scores.get(metric) for metric in metrics},
+                insights=analysis.results.get("insights", []),
+                risks=analysis.results.get("risks", []),
+            )
+        )
+    return AnalysisComparisonPublic(metrics=metrics, columns=columns)
+
+
+def _risk_key(risk: Any) -> str:
+    """Stable identity for a risk so versions can be matched up."""
+    if isinstance(risk, dict):
+        return f"{risk.get('category', 'risk')}: {risk.get('description', '')}"
+    return str(risk)
+
+
+@router.get("/{id}/diff", response_model=AnalysisDiffPublic)
+def diff_analyses(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    against: uuid.UUID,
+) -> Any:
+    """
+    Diff two completed analyses of the same company: which metrics moved,
+    which risks were resolved or newly introduced, and whether the
+    recommendation changed. ``against`` is the older baseline.
+    """
+    analysis = get_analysis_for_user(session, current_user, id)
+    baseline = get_analysis_for_user(session, current_user, against)
+    if analysis.company_id != baseline.company_id:
+        raise HTTPException(
+            status_code=422, detail="Analyses belong to different companies"
+        )
+    for candidate in (analysis, baseline):
+        if candidate.status != AnalysisStatus.COMPLETED:
+            raise HTTPException(
+                status_code=409,
+                detail=f"Analysis {candidate.id} is not completed",
+            )
+    before_scores = baseline.results.get("scores", {})
+    after_scores = analysis.results.get("scores", {})
+    metrics = []
+    for metric in {**before_scores, **after_scores}:
+        if metric == "overall":
+            continue
+        before = before_scores.get(metric)
+        after = after_scores.get(metric)
+        metrics.append(
+            MetricDelta(
+                metric=metric,
+                before=before,
+                after=after,
+                delta=(
+                    round(after - before, 2)
+                    if before is not None and after is not None
+                    else None
+                ),
+            )
+        )
+    before_risks = baseline.results.get("risks", [])
+    after_risks = analysis.results.get("risks", [])
+    after_keys = {_risk_key(risk) for risk in after_risks}
+    before_keys = {_risk_key(risk) for risk in before_risks}
+    before_rec = analysis_recommendation(baseline)
+    after_rec = analysis_recommendation(analysis)
+    return AnalysisDiffPublic(
+        analysis_id=analysis.id,
+        against_id=baseline.id,
+        overall_delta=(
+            round(analysis.score - baseline.score, 2)
+            if analysis.score is not None and baseline.score is not None
+            else None
+        ),
+        metrics=metrics,
+        risks_resolved=[
+            risk for risk in before_risks if _risk_key(risk) not in after_keys
+        ],
+        risks_introduced=[
+            risk for risk in after_risks if _risk_key(risk) not in before_keys
+        ],
+        recommendation_before=before_rec,
+        recommendation_after=after_rec,
+        recommendation_changed=before_rec != after_rec,
+    )
+
+
+@router.post("/{id}/unit-economics", response_model=AnalysisPublic)
+def set_unit_economics(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    inputs_in: UnitEconomicsInput,
+) -> Any:
+    """
+    Supply unit-economics inputs by hand, overriding anything extracted
+    from documents, and recompute the metrics and commentary.
+
+    Completed analyses are immutable, so on a completed analysis this
+    returns a new version with the recomputed results; otherwise the
+    analysis is updated in place.
+    """
+    analysis = get_analysis_for_user(session, current_user, id)
+    company = session.get(Company, analysis.company_id)
+    existing = analysis.results.get("unit_economics", {}).get("metrics", {})
+    merged = {
+        key: existing[key]
+        for key in UnitEconomicsInput.model_fields
+        if key in existing
+    }
+    merged.update(
+        {
+            key: value
+            for key, value in inputs_in.model_dump(exclude_unset=True).items()
+            if value is not None
+        }
+    )
+    if not merged:
+        raise HTTPException(
+            status_code=422, detail="At least one input is required"
+        )
+    results = {
+        **analysis.results,
+        "unit_economics": financials.compute_unit_economics(
+            merged, company.industry if company else None
+        ),
+    }
+    if analysis.status == AnalysisStatus.COMPLETED:
+        version, supersedes_id = next_version(session, analysis.company_id)
+        analysis = Analysis(
+            company_id=analysis.company_id,
+            requested_by=current_user.id,
+            status=AnalysisStatus.COMPLETED,
+            progress=100,
+            score=analysis.score,
+            monthly_burn_usd=analysis.monthly_burn_usd,
+            cash_on_hand_usd=analysis.cash_on_hand_usd,
+            runway_months=analysis.runway_months,
+            results=results,
+            trace_id=tracing.get_request_id(),
+            version=version,
+            supersedes_id=supersedes_id,
+            completed_at=utcnow(),
+        )
+    else:
+        analysis.results = results
+    session.add(analysis)
+    session.commit()
+    session.refresh(analysis)
+    return analysis
+
+
+@router.post("/{id}/override", response_model=AnalysisPublic)
+def override_scores(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    override_in: ScoreOverrideRequest,
+) -> Any:
+    """
+    Adjust individual metric values or the recommendation by hand. The
+    pipeline's original values are preserved and every override is
+    recorded with who, when, and why.
+    """
+    analysis = get_analysis_for_user(session, current_user, id)
+    if analysis.status != AnalysisStatus.COMPLETED:
+        raise HTTPException(status_code=409, detail="Analysis is not completed")
+    if not override_in.metrics and override_in.recommendation is None:
+        raise HTTPException(
+            status_code=422, detail="At least one override is required"
+        )
+    scores = analysis.results.get("scores", {})
+    for metric, value in override_in.metrics.items():
+        if metric == "overall" or metric not in scores:
+            raise HTTPException(
+                status_code=422, detail=f"Unknown metric '{metric}'"
+            )
+        if not 0 <= value <= 10:
+            raise HTTPException(
+                status_code=422,
+                detail=f"Value for '{metric}' must be between 0 and 10",
+            )
+    apply_score_overrides(
+        session,
+        analysis,
+        override_in.metrics,
+        override_in.recommendation,
+        current_user.id,
+        override_in.reason,
+    )
+    session.add(analysis)
+    session.commit()
+    session.refresh(analysis)
+    audit.emit(
+        "analysis.scores_overridden",
+        actor_id=current_user.id,
+        organization_id=current_user.organization_id,
+        data={
+            "analysis_id": str(analysis.id),
+            "metrics": list(override_in.metrics),
+            "reason": override_in.reason,
+        },
+    )
+    return analysis
+
+
+@router.put("/{id}/assignee", response_model=AnalysisPublic)
+def assign_analysis(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    assignee_in: AssigneeUpdate,
+) -> Any:
+    """
+    Assign an analyst to work an analysis; null clears the assignment.
+    """
+    analysis = get_analysis_for_user(session, current_user, id)
+    if assignee_in.assignee_id is not None:
+        assignee = session.get(User, assignee_in.assignee_id)
+        if not assignee or not assignee.is_active:
+            raise HTTPException(status_code=404, detail="Assignee not found")
+        if not current_user.is_superuser and (
+            assignee.organization_id != current_user.organization_id
+        ):
+            raise HTTPException(
+                status_code=400, detail="Assignee is not in your organization"
+            )
+    analysis.assignee_id = assignee_in.assignee_id
+    session.add(analysis)
+    session.commit()
+    session.refresh(analysis)
+    return analysis
+
+
+@router.post("/{id}/review/request", response_model=AnalysisPublic)
+def request_review(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    submit_in: ReviewSubmit,
+) -> Any:
+    """
+    Submit a completed analysis for human review, optionally assigning a
+    specific reviewer from the organization.
+    """
+    analysis = get_analysis_for_user(session, current_user, id)
+    if analysis.status != AnalysisStatus.COMPLETED:
+        raise HTTPException(status_code=409, detail="Analysis is not completed")
+    if analysis.review_state == ReviewState.IN_REVIEW:
+        raise HTTPException(status_code=409, detail="Analysis is already in review")
+    if submit_in.reviewer_id is not None:
+        reviewer = session.get(User, submit_in.reviewer_id)
+        if not reviewer or not reviewer.is_active:
+            raise HTTPException(status_code=404, detail="Reviewer not found")
+        if not current_user.is_superuser and (
+            reviewer.organization_id != current_user.organization_id
+        ):
+            raise HTTPException(
+                status_code=400, detail="Reviewer is not in your organization"
+            )
+    analysis.review_state = ReviewState.IN_REVIEW
+    analysis.reviewer_id = submit_in.reviewer_id
+    analysis.review_note = None
+    analysis.reviewed_at = None
+    session.add(analysis)
+    session.commit()
+    session.refresh(analysis)
+    return analysis
+
+
+@router.post("/{id}/review", response_model=AnalysisPublic)
+def review_analysis(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    decision: ReviewDecision,
+) -> Any:
+    """
+    Record the review decision on an analysis awaiting review. Assigned
+    reviews are decided by their reviewer; unassigned reviews by anyone in
+    the organization other than the requester.
+    """
+    analysis = session.get(Analysis, id)
+    if not analysis:
+        raise HTTPException(status_code=404, detail="Analysis not found")
+    company = session.get(Company, analysis.company_id)
+    if company and company.deleted_at is not None:
+        raise HTTPException(status_code=404, detail="Analysis not found")
+    if analysis.review_state != ReviewState.IN_REVIEW:
+        raise HTTPException(
+            status_code=409, detail="Analysis is not awaiting review"
+        )
+    if not current_user.is_superuser:
+        if analysis.reviewer_id is not None:
+            if analysis.reviewer_id != current_user.id:
+                raise HTTPException(
+                    status_code=400, detail="Not enough permissions"
+                )
+        else:
+            owner = session.get(User, company.owner_id) if company else None
+            if (
+                not owner
+                or owner.organization_id is None
+                or owner.organization_id != current_user.organization_id
+            ):
+                raise HTTPException(
+                    status_code=400, detail="Not enough permissions"
+                )
+        if analysis.requested_by == current_user.id:
+            raise HTTPException(
+                status_code=400,
+                detail="Requesters cannot review their own analysis",
+            )
+    analysis.review_state = (
+        ReviewState.APPROVED if decision.approved else ReviewState.REJECTED
+    )
+    analysis.reviewer_id = current_user.id
+    analysis.review_note = decision.note
+    analysis.reviewed_at = utcnow()
+    session.add(analysis)
+    session.commit()
+    session.refresh(analysis)
+    audit.emit(
+        "analysis.reviewed",
+        actor_id=current_user.id,
+        organization_id=current_user.organization_id,
+        data={
+            "analysis_id": str(analysis.id),
+            "approved": decision.approved,
+        },
+    )
+    return analysis
+
+
+@router.get("/{id}", response_model=AnalysisPublic)
+def read_analysis(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    request: Request,
+    response: Response,
+) -> Any:
+    """
+    Get analysis by ID. Returns an ETag and honors ``If-None-Match``.
+    """
+    analysis = get_analysis_for_user(session, current_user, id)
+    public = AnalysisPublic.model_validate(
+        analysis, update={"is_stale": is_stale(session, analysis)}
+    )
+    etag = compute_etag(public)
+    if is_not_modified(request, etag):
+        return Response(status_code=304, headers={"ETag": etag})
+    response.headers["ETag"] = etag
+    return public
+
+
+@router.post("/{id}/refresh", response_model=AnalysisPublic)
+def refresh_analysis(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    background_tasks: BackgroundTasks,
+) -> Any:
+    """
+    Re-run a stale analysis. Previous results seed the new run so stages
+    whose inputs are unchanged can reuse them.
+    """
+    analysis = get_analysis_for_user(session, current_user, id)
+    if analysis.status != AnalysisStatus.COMPLETED:
+        raise HTTPException(status_code=409, detail="Analysis is not completed")
+    version, supersedes_id = next_version(session, analysis.company_id)
+    refreshed = Analysis(
+        company_id=analysis.company_id,
+        requested_by=current_user.id,
+        results=dict(analysis.results),
+        trace_id=tracing.get_request_id(),
+        version=version,
+        supersedes_id=supersedes_id,
+    )
+    session.add(refreshed)
+    session.commit()
+    session.refresh(refreshed)
+    background_tasks.add_task(run_analysis, refreshed.id)
+    return refreshed
+
+
+# Long-poll waits are capped so load balancers don't reap the connection.
+MAX_WAIT_SECONDS = 60
+
+
+def _parse_wait(wait: str) -> float:
+    match = re.fullmatch(r"(\d+)s?", wait)
+    if not match:
+        raise HTTPException(
+            status_code=422, detail="wait must be seconds, e.g. wait=30s"
+        )
+    return min(float(match.group(1)), MAX_WAIT_SECONDS)
+
+
+@router.get("/{id}/status", response_model=AnalysisStatusPublic)
+async def read_analysis_status(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    wait: str | None = None,
+) -> Any:
+    """
+    Get the current status of an analysis.
+
+    With ``?wait=30s`` the request is held until the status, stage, or
+    progress changes or the timeout expires — a long-polling fallback for
+    clients that can't use SSE or WebSockets.
+    """
+    analysis = get_analysis_for_user(session, current_user, id)
+    if wait is None:
+        return analysis
+    timeout = _parse_wait(wait)
+    if analysis.status in (AnalysisStatus.COMPLETED, AnalysisStatus.FAILED):
+        return analysis
+    topic = f"analysis:{analysis.id}"
+    before = (analysis.status, analysis.stage, analysis.progress)
+    queue_ = broker.subscribe(topic)
+    try:
+        deadline = asyncio.get_running_loop().time() + timeout
+        while True:
+            remaining = deadline - asyncio.get_running_loop().time()
+            if remaining <= 0:
+                break
+            try:
+                await asyncio.wait_for(queue_.get(), timeout=remaining)
+            except TimeoutError:
+                break
+            session.refresh(analysis)
+            if (analysis.status, analysis.stage, analysis.progress) != before:
+                break
+    finally:
+        broker.unsubscribe(topic, queue_)
+    session.refresh(analysis)
+    return analysis
+
+
+REPORT_FORMATS = {
+    "pdf": (
+        build_pdf_report,
+        "application/pdf",
+    ),
+    "docx": (
+        build_docx_report,
+        "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+    ),
+    "html": (
+        build_html_report,
+        "text/html",
+    ),
+}
+
+
+def build_report_response(
+    session: SessionDep, analysis: Analysis, format: str, lang: str = "en"
+) -> Response:
+    """Render a completed analysis into a report response. Shared by the
+    authenticated report route and signed download links."""
+    if format not in REPORT_FORMATS:
+        raise HTTPException(status_code=422, detail="Unknown report format")
+    if lang not in SUPPORTED_LOCALES:
+        raise HTTPException(status_code=422, detail="Unsupported language")
+    if analysis.status != AnalysisStatus.COMPLETED:
+        raise HTTPException(status_code=409, detail="Analysis is not completed")
+    company = session.get(Company, analysis.company_id)
+    if not company:
+        raise HTTPException(status_code=404, detail="Company not found")
+    owner = session.get(User, company.owner_id)
+    organization_id = owner.organization_id if owner else None
+    template = get_active_template(session, organization_id)
+    branding = get_branding(session, organization_id)
+    builder, media_type = REPORT_FORMATS[format]
+    content = builder(analysis, company, template, branding, lang)
+    filename = f"{company.name.replace(' ', '_')}_analysis.{format}"
+    # HTML renders in the browser for printing/sharing; binaries download.
+    disposition = "inline" if format == "html" else "attachment"
+    return Response(
+        content=content,
+        media_type=media_type,
+        headers={"Content-Disposition": f'{disposition}; filename="{filename}"'},
+    )
+
+
+@router.get("/{id}/report")
+def read_analysis_report(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    format: str = "pdf",
+    lang: str = "en",
+) -> Response:
+    """
+    Download the analysis report as a PDF or an editable Word document,
+    optionally localized with ``?lang=de``.
+    """
+    analysis = get_analysis_for_user(session, current_user, id)
+    return build_report_response(session, analysis, format, lang)
+
+
+@router.post("/{id}/report/link", response_model=DownloadLinkPublic)
+def create_report_link(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    request: Request,
+    format: str = "pdf",
+    lang: str = "en",
+) -> Any:
+    """
+    Create a signed, expiring link to the report, safe to share in email.
+    """
+    if format not in REPORT_FORMATS:
+        raise HTTPException(status_code=422, detail="Unknown report format")
+    if lang not in SUPPORTED_LOCALES:
+        raise HTTPException(status_code=422, detail="Unsupported language")
+    analysis = get_analysis_for_user(session, current_user, id)
+    if analysis.status != AnalysisStatus.COMPLETED:
+        raise HTTPException(status_code=409, detail="Analysis is not completed")
+    token, expires_at = generate_download_token(
+        "report", analysis.id, format=format, lang=lang
+    )
+    return DownloadLinkPublic(
+        url=str(request.url_for("download_resource", token=token)),
+        expires_at=expires_at,
+    )
+
+
+@router.post("/{id}/export/notion", response_model=NotionPagePublic)
+async def export_analysis_to_notion(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Any:
+    """
+    Push the analysis memo into the organization's configured Notion database.
+    """
+    analysis = get_analysis_for_user(session, current_user, id)
+    if analysis.status != AnalysisStatus.COMPLETED:
+        raise HTTPException(status_code=409, detail="Analysis is not completed")
+    if current_user.organization_id is None:
+        raise HTTPException(status_code=400, detail="User has no organization")
+    organization = session.get(Organization, current_user.organization_id)
+    if not organization:
+        raise HTTPException(status_code=404, detail="Organization not found")
+    url = await notion.export_memo(session, organization, analysis)
+    return NotionPagePublic(url=url)
+
+
+def _sse_message(data: dict[str, Any], event: str | None = None) -> str:
+    message = f"data: {json.dumps(data)}\n\n"
+    if event:
+        message = f"event: {event}\n{message}"
+    return message
+
+
+@router.get("/{id}/events")
+async def stream_analysis_events(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> StreamingResponse:
+    """
+    Stream analysis progress as server-sent events.
+
+    Emits a snapshot immediately, then one event per status, stage, or
+    partial-result change until the analysis reaches a terminal state.
+    """
+    analysis = get_analysis_for_user(session, current_user, id)
+    topic = f"analysis:{analysis.id}"
+    snapshot = {
+        "id": str(analysis.id),
+        "status": analysis.status,
+        "stage": analysis.stage,
+        "progress": analysis.progress,
+    }
+    terminal = analysis.status in (AnalysisStatus.COMPLETED, AnalysisStatus.FAILED)
+
+    async def event_stream() -> AsyncGenerator[str, None]:
+        yield _sse_message(snapshot, event="status")
+        if terminal:
+            return
+        queue = broker.subscribe(topic)
+        try:
+            while True:
+                try:
+                    event = await asyncio.wait_for(
+                        queue.get(), timeout=SSE_KEEPALIVE_SECONDS
+                    )
+                except TimeoutError:
+                    yield ": keep-alive\n\n"
+                    continue
+                yield _sse_message(event, event="status")
+                if event.get("status") in (
+                    AnalysisStatus.COMPLETED,
+                    AnalysisStatus.FAILED,
+                ):
+                    return
+        finally:
+            broker.unsubscribe(topic, queue)
+
+    return StreamingResponse(
+        event_stream(),
+        media_type="text/event-stream",
+        headers={"Cache-Control": "no-cache", "X-Accel-Buffering": "no"},
+    )
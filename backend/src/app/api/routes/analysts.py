@@ -0,0 +1,82 @@
+import uuid
+from typing import Any
+
+from fastapi import APIRouter, HTTPException
+from sqlmodel import col, select
+
+from app.api.deps import CurrentUser, SessionDep
+from app.models import (
+    Analysis,
+    AnalystQueuePublic,
+    Company,
+    PipelineStage,
+    ReviewState,
+    User,
+)
+
+router = APIRouter(prefix="/analysts", tags=["analysts"])
+
+# Stages with nothing left to do; assigned deals there are not open work
+CLOSED_STAGES = [PipelineStage.INVESTED, PipelineStage.PASSED]
+
+
+def get_analyst_for_user(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> User:
+    analyst = session.get(User, id)
+    if not analyst or not analyst.is_active:
+        raise HTTPException(status_code=404, detail="Analyst not found")
+    if (
+        not current_user.is_superuser
+        and current_user.id != analyst.id
+        and (
+            current_user.organization_id is None
+            or current_user.organization_id != analyst.organization_id
+        )
+    ):
+        raise HTTPException(status_code=400, detail="Not enough permissions")
+    return analyst
+
+
+@router.get("/{id}/queue", response_model=AnalystQueuePublic)
+def read_analyst_queue(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Any:
+    """
+    An analyst's open work: assigned deals still moving through the
+    pipeline, assigned analyses not yet approved, and reviews waiting on
+    them. Team leads use this to balance diligence load.
+    """
+    analyst = get_analyst_for_user(session, current_user, id)
+    companies = session.exec(
+        select(Company)
+        .where(
+            Company.assignee_id == analyst.id,
+            col(Company.deleted_at).is_(None),
+            col(Company.archived).is_(False),
+            col(Company.pipeline_stage).notin_(CLOSED_STAGES),
+        )
+        .order_by(col(Company.created_at))
+    ).all()
+    analyses = session.exec(
+        select(Analysis)
+        .where(
+            Analysis.assignee_id == analyst.id,
+            Analysis.review_state != ReviewState.APPROVED,
+        )
+        .order_by(col(Analysis.created_at))
+    ).all()
+    reviews = session.exec(
+        select(Analysis)
+        .where(
+            Analysis.reviewer_id == analyst.id,
+            Analysis.review_state == ReviewState.IN_REVIEW,
+        )
+        .order_by(col(Analysis.created_at))
+    ).all()
+    return AnalystQueuePublic(
+        analyst_id=analyst.id,
+        companies=companies,
+        analyses=analyses,
+        reviews=reviews,
+    )
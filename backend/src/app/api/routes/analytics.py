@@ -0,0 +1,29 @@
+from typing import Any
+
+from fastapi import APIRouter
+
+from app.api.deps import CurrentUser, SessionDep
+from app.api.routes.analysis import company_scope
+from app.models import AnalysisTrendsPublic, SectorHeatmapPublic
+from app.services import analytics
+
+router = APIRouter(prefix="/analytics", tags=["analytics"])
+
+
+@router.get("/sectors", response_model=SectorHeatmapPublic)
+def read_sector_heatmap(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Aggregate average scores, deal counts, and momentum by industry across
+    the organization's completed analyses, best-scoring sectors first.
+    """
+    stats = analytics.sector_heatmap(session, company_scope(current_user))
+    return SectorHeatmapPublic(data=stats, count=len(stats))
+
+
+@router.get("/trends", response_model=AnalysisTrendsPublic)
+def read_analysis_trends(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    How scores, risk counts, and recommendations trend across the
+    organization's completed analyses, by month and by company stage.
+    """
+    return analytics.score_trends(session, company_scope(current_user))
@@ -0,0 +1,57 @@
+from typing import Any
+
+from fastapi import APIRouter, Depends
+from sqlmodel import col, select
+
+from app.api.deps import SessionDep, get_current_active_superuser
+from app.models import (
+    Benchmark,
+    BenchmarksImport,
+    BenchmarksPublic,
+    Message,
+)
+
+router = APIRouter(prefix="/benchmarks", tags=["benchmarks"])
+
+
+@router.get("/", response_model=BenchmarksPublic)
+def read_benchmarks(
+    session: SessionDep, industry: str | None = None, metric: str | None = None
+) -> Any:
+    """
+    Retrieve benchmark cohorts, optionally filtered by industry or metric.
+    """
+    statement = select(Benchmark)
+    if industry is not None:
+        statement = statement.where(col(Benchmark.industry).ilike(industry))
+    if metric is not None:
+        statement = statement.where(Benchmark.metric == metric)
+    benchmarks = session.exec(
+        statement.order_by(col(Benchmark.industry), col(Benchmark.metric))
+    ).all()
+    return BenchmarksPublic(data=benchmarks, count=len(benchmarks))
+
+
+@router.post(
+    "/import",
+    dependencies=[Depends(get_current_active_superuser)],
+    response_model=Message,
+)
+def import_benchmarks(*, session: SessionDep, import_in: BenchmarksImport) -> Any:
+    """
+    Import benchmark cohorts, replacing any existing cohort with the same
+    industry, stage, and metric. Superusers only.
+    """
+    for row in import_in.rows:
+        existing = session.exec(
+            select(Benchmark).where(
+                Benchmark.industry == row.industry,
+                Benchmark.stage == row.stage,
+                Benchmark.metric == row.metric,
+            )
+        ).all()
+        for benchmark in existing:
+            session.delete(benchmark)
+        session.add(Benchmark.model_validate(row))
+    session.commit()
+    return Message(message=f"Imported {len(import_in.rows)} benchmark cohorts")
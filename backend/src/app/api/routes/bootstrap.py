@@ -0,0 +1,52 @@
+from typing import Any
+
+from fastapi import APIRouter
+
+from app.api.deps import CurrentUser, SessionDep
+from app.core.config import settings
+from app.models import (
+    INDUSTRIES,
+    AnalysisStage,
+    AnalysisStatus,
+    BootstrapPublic,
+    CompanyStage,
+    Organization,
+    UserRole,
+)
+
+router = APIRouter(tags=["bootstrap"])
+
+# Server-enforced limits mirrored to clients so forms can validate up front.
+LIMITS = {
+    "max_page_size": 100,
+    "max_upload_size_mb": 50,
+    "max_companies_per_request": 100,
+}
+
+
+@router.get("/bootstrap", response_model=BootstrapPublic)
+def read_bootstrap(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Get feature flags, enum values, limits, and caller settings in one call.
+    """
+    organization = (
+        session.get(Organization, current_user.organization_id)
+        if current_user.organization_id
+        else None
+    )
+    return BootstrapPublic(
+        features={
+            "emails": settings.emails_enabled,
+            "sentry": settings.SENTRY_DSN is not None,
+        },
+        enums={
+            "industries": INDUSTRIES,
+            "company_stages": [stage.value for stage in CompanyStage],
+            "analysis_statuses": [status.value for status in AnalysisStatus],
+            "analysis_stages": [stage.value for stage in AnalysisStage],
+            "user_roles": [role.value for role in UserRole],
+        },
+        limits=LIMITS,
+        user=current_user,
+        organization=organization,
+    )
@@ -0,0 +1,557 @@
+import uuid
+from typing import Any
+
+from fastapi import APIRouter, BackgroundTasks, Body, HTTPException, Request, Response
+from fastapi.encoders import jsonable_encoder
+from fastapi.responses import JSONResponse
+from sqlmodel import and_, col, func, or_, select
+
+from app.api import idempotency
+from app.api.conditional import compute_etag, is_not_modified, require_if_match
+from app.api.deps import CurrentUser, SessionDep
+from app.api.listing import (
+    decode_cursor,
+    encode_cursor,
+    parse_ids,
+    parse_sort,
+    select_fields,
+)
+from app.models import (
+    AnalysesPublic,
+    Analysis,
+    AnalysisStatus,
+    AssigneeUpdate,
+    CompaniesPublic,
+    Company,
+    CompanyBulkTransfer,
+    CompanyCreate,
+    CompanyPublic,
+    CompanyStageUpdate,
+    CompanyTag,
+    CompanyTransfer,
+    CompanyUpdate,
+    Message,
+    MetricHistoryPoint,
+    MetricHistoryPublic,
+    PipelineStage,
+    ReserveRecommendationPublic,
+    ReserveRequest,
+    Tag,
+    UploadedFile,
+    UploadedFilesPublic,
+    User,
+    utcnow,
+)
+from app.services import duplicates, privacy, reserves
+from app.services.bus import publish_domain_event
+
+router = APIRouter(prefix="/companies", tags=["companies"])
+
+COMPANY_SORTABLE = {
+    "name": Company.name,
+    "industry": Company.industry,
+    "stage": Company.stage,
+    "created_at": Company.created_at,
+}
+
+
+def get_company_for_user(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    include_deleted: bool = False,
+) -> Company:
+    company = session.get(Company, id)
+    if not company or (company.deleted_at is not None and not include_deleted):
+        raise HTTPException(status_code=404, detail="Company not found")
+    if not current_user.is_superuser and (company.owner_id != current_user.id):
+        raise HTTPException(status_code=400, detail="Not enough permissions")
+    return company
+
+
+def _validate_transfer_target(
+    session: SessionDep, current_user: CurrentUser, new_owner_id: uuid.UUID
+) -> User:
+    new_owner = session.get(User, new_owner_id)
+    if not new_owner or not new_owner.is_active:
+        raise HTTPException(status_code=404, detail="New owner not found")
+    if not current_user.is_superuser and (
+        new_owner.organization_id != current_user.organization_id
+    ):
+        raise HTTPException(
+            status_code=400, detail="New owner is not in your organization"
+        )
+    return new_owner
+
+
+@router.get("/", response_model=CompaniesPublic)
+def read_companies(
+    session: SessionDep,
+    current_user: CurrentUser,
+    page: int = 1,
+    per_page: int = 50,
+    sort: str | None = None,
+    fields: str | None = None,
+    ids: str | None = None,
+    cursor: str | None = None,
+    include_archived: bool = False,
+    tag: str | None = None,
+) -> Any:
+    """
+    Retrieve companies, newest first by default. ``count`` is the total
+    matching rows so the dashboard can render page controls. ``sort`` takes
+    comma-separated fields, ``-`` prefix for descending; ``fields`` limits
+    each row to the named fields; ``ids`` bulk-fetches specific companies;
+    ``tag`` keeps only companies carrying one of the caller's tags by name.
+    Archived companies stay hidden unless ``include_archived=true``.
+
+    Pass ``cursor=`` (empty for the first page) to page by keyset instead of
+    offset; follow ``next_cursor`` until it is null.
+    """
+    if page < 1 or per_page < 1 or per_page > 100:
+        raise HTTPException(status_code=422, detail="Invalid pagination parameters")
+    count_statement = (
+        select(func.count())
+        .select_from(Company)
+        .where(col(Company.deleted_at).is_(None))
+    )
+    statement = select(Company).where(col(Company.deleted_at).is_(None))
+    if not include_archived:
+        count_statement = count_statement.where(Company.archived == False)  # noqa: E712
+        statement = statement.where(Company.archived == False)  # noqa: E712
+    if ids is not None:
+        id_filter = col(Company.id).in_(parse_ids(ids))
+        count_statement = count_statement.where(id_filter)
+        statement = statement.where(id_filter)
+    if tag is not None:
+        tag_filter = col(Company.id).in_(
+            select(CompanyTag.company_id)
+            .join(Tag)
+            .where(Tag.name == tag, Tag.owner_id == current_user.id)
+        )
+        count_statement = count_statement.where(tag_filter)
+        statement = statement.where(tag_filter)
+    if not current_user.is_superuser:
+        count_statement = count_statement.where(Company.owner_id == current_user.id)
+        statement = statement.where(Company.owner_id == current_user.id)
+    count = session.exec(count_statement).one()
+    next_cursor = None
+    if cursor is not None:
+        if sort is not None:
+            raise HTTPException(
+                status_code=422, detail="Cursor pagination uses a fixed order"
+            )
+        if cursor:
+            created_at, last_id = decode_cursor(cursor)
+            statement = statement.where(
+                or_(
+                    col(Company.created_at) < created_at,
+                    and_(
+                        col(Company.created_at) == created_at,
+                        col(Company.id) < last_id,
+                    ),
+                )
+            )
+        companies = session.exec(
+            statement.order_by(
+                col(Company.created_at).desc(), col(Company.id).desc()
+            ).limit(per_page)
+        ).all()
+        if len(companies) == per_page:
+            next_cursor = encode_cursor(companies[-1].created_at, companies[-1].id)
+    else:
+        order_by = parse_sort(
+            sort, COMPANY_SORTABLE, [col(Company.created_at).desc()]
+        )
+        companies = session.exec(
+            statement.order_by(*order_by)
+            .offset((page - 1) * per_page)
+            .limit(per_page)
+        ).all()
+    if fields is not None:
+        return JSONResponse(
+            content=jsonable_encoder(
+                {
+                    "data": select_fields(companies, fields, CompanyPublic),
+                    "count": count,
+                    "next_cursor": next_cursor,
+                }
+            )
+        )
+    return CompaniesPublic(data=companies, count=count, next_cursor=next_cursor)
+
+
+@router.post("/", response_model=CompanyPublic)
+def create_company(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    company_in: CompanyCreate,
+    background_tasks: BackgroundTasks,
+    request: Request,
+    force: bool = False,
+) -> Any:
+    """
+    Create new company. Retries carrying the same ``Idempotency-Key`` header
+    replay the first response instead of creating a duplicate. Likely
+    duplicates (same normalized name or website domain as an existing
+    company) are rejected with 409 and the matching candidates; pass
+    ``force=true`` to create the company anyway.
+    """
+    key = idempotency.request_key(request)
+    if key:
+        cached = idempotency.cached_response(
+            session, current_user.id, "create_company", key
+        )
+        if cached:
+            return cached
+    if not force:
+        candidates = duplicates.find_duplicates(
+            session, current_user, company_in.name, company_in.website
+        )
+        if candidates:
+            raise HTTPException(
+                status_code=409,
+                detail={
+                    "message": "A similar company already exists",
+                    "candidates": [
+                        {
+                            "id": str(candidate.id),
+                            "name": candidate.name,
+                            "website": candidate.website,
+                        }
+                        for candidate in candidates
+                    ],
+                },
+            )
+    company = Company.model_validate(company_in, update={"owner_id": current_user.id})
+    session.add(company)
+    session.commit()
+    session.refresh(company)
+    background_tasks.add_task(
+        publish_domain_event,
+        "company.created",
+        {"company_id": str(company.id), "name": company.name},
+    )
+    if key:
+        idempotency.store_response(
+            session,
+            current_user.id,
+            "create_company",
+            key,
+            CompanyPublic.model_validate(company),
+        )
+    return company
+
+
+# Declared before /{id} so "transfer" is never parsed as an ID.
+@router.post("/transfer", response_model=Message)
+def bulk_transfer_companies(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    transfer_in: CompanyBulkTransfer,
+) -> Any:
+    """
+    Reassign all companies from one analyst to another.
+    """
+    from_owner = session.get(User, transfer_in.from_owner_id)
+    if not from_owner:
+        raise HTTPException(status_code=404, detail="Current owner not found")
+    if not current_user.is_superuser and (
+        from_owner.organization_id != current_user.organization_id
+    ):
+        raise HTTPException(status_code=400, detail="Not enough permissions")
+    _validate_transfer_target(session, current_user, transfer_in.to_owner_id)
+    statement = select(Company).where(Company.owner_id == transfer_in.from_owner_id)
+    companies = session.exec(statement).all()
+    for company in companies:
+        company.owner_id = transfer_in.to_owner_id
+        session.add(company)
+    session.commit()
+    return Message(message=f"Transferred {len(companies)} companies")
+
+
+@router.post("/{id}/transfer", response_model=CompanyPublic)
+def transfer_company(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    transfer_in: CompanyTransfer,
+) -> Any:
+    """
+    Reassign a company and its analyses to another analyst.
+    """
+    company = get_company_for_user(session, current_user, id)
+    _validate_transfer_target(session, current_user, transfer_in.new_owner_id)
+    company.owner_id = transfer_in.new_owner_id
+    session.add(company)
+    session.commit()
+    session.refresh(company)
+    return company
+
+
+@router.put("/{id}/assignee", response_model=CompanyPublic)
+def assign_company(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    assignee_in: AssigneeUpdate,
+) -> Any:
+    """
+    Assign an analyst to drive diligence on a company; null clears it.
+    """
+    company = get_company_for_user(session, current_user, id)
+    if assignee_in.assignee_id is not None:
+        _validate_transfer_target(session, current_user, assignee_in.assignee_id)
+    company.assignee_id = assignee_in.assignee_id
+    session.add(company)
+    session.commit()
+    session.refresh(company)
+    return company
+
+
+@router.patch("/{id}", response_model=CompanyPublic)
+def update_company(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    request: Request,
+    patch: dict[str, Any] = Body(...),
+) -> Any:
+    """
+    Update a company with JSON Merge Patch semantics (RFC 7386): absent
+    fields stay untouched, fields present as ``null`` are cleared. Requires
+    ``If-Match`` with the company's current ETag.
+    """
+    company = get_company_for_user(session, current_user, id)
+    require_if_match(request, compute_etag(CompanyPublic.model_validate(company)))
+    unknown = [key for key in patch if key not in CompanyUpdate.model_fields]
+    if unknown:
+        raise HTTPException(
+            status_code=422, detail=f"Unknown fields: {', '.join(unknown)}"
+        )
+    if patch.get("name", "unset") is None:
+        raise HTTPException(status_code=422, detail="name cannot be cleared")
+    # Validates types and constraints; nulls survive into the update dict so
+    # they clear their columns, unlike exclude_unset-style updates.
+    validated = CompanyUpdate.model_validate(
+        {key: value for key, value in patch.items() if value is not None}
+    )
+    update_dict = validated.model_dump(exclude_unset=True)
+    update_dict.update({key: None for key, value in patch.items() if value is None})
+    company.sqlmodel_update(update_dict)
+    session.add(company)
+    session.commit()
+    session.refresh(company)
+    return company
+
+
+@router.post("/{id}/stage", response_model=CompanyPublic)
+def update_company_stage(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    stage_in: CompanyStageUpdate,
+) -> Any:
+    """
+    Move a company to another pipeline stage.
+    """
+    company = get_company_for_user(session, current_user, id)
+    if stage_in.pipeline_stage != company.pipeline_stage:
+        company.stage_changed_at = utcnow()
+    company.pipeline_stage = stage_in.pipeline_stage
+    session.add(company)
+    session.commit()
+    session.refresh(company)
+    return company
+
+
+@router.post("/{id}/archive", response_model=CompanyPublic)
+def archive_company(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Any:
+    """
+    Archive a company, hiding it from default lists while keeping history.
+    """
+    company = get_company_for_user(session, current_user, id)
+    company.archived = True
+    session.add(company)
+    session.commit()
+    session.refresh(company)
+    return company
+
+
+@router.post("/{id}/unarchive", response_model=CompanyPublic)
+def unarchive_company(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Any:
+    """
+    Bring an archived company back into the active pipeline.
+    """
+    company = get_company_for_user(session, current_user, id)
+    company.archived = False
+    session.add(company)
+    session.commit()
+    session.refresh(company)
+    return company
+
+
+@router.delete("/{id}")
+def delete_company(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    purge: bool = False,
+) -> Message:
+    """
+    Soft-delete a company, hiding it and its analyses while preserving
+    history. ``?purge=true`` permanently removes the company and everything
+    attached to it; superusers only.
+    """
+    company = get_company_for_user(session, current_user, id, include_deleted=purge)
+    if purge:
+        if not current_user.is_superuser:
+            raise HTTPException(
+                status_code=403, detail="The user doesn't have enough privileges"
+            )
+        session.delete(company)
+        session.commit()
+        return Message(message="Company purged")
+    company.deleted_at = utcnow()
+    session.add(company)
+    session.commit()
+    return Message(message="Company deleted")
+
+
+@router.post("/{id}/erase")
+def erase_company(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Any:
+    """
+    Permanently erase a company and everything derived from it — stored
+    files, extracted text, analyses, vectors, caches — and return a
+    verification report. For GDPR erasure requests; superusers only.
+    """
+    if not current_user.is_superuser:
+        raise HTTPException(
+            status_code=403, detail="The user doesn't have enough privileges"
+        )
+    company = get_company_for_user(session, current_user, id, include_deleted=True)
+    return privacy.erase_company(session, company)
+
+
+@router.get("/{id}/files", response_model=UploadedFilesPublic)
+def read_company_files(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Any:
+    """
+    Retrieve the files attached to a company, newest first.
+    """
+    company = get_company_for_user(session, current_user, id)
+    files = session.exec(
+        select(UploadedFile)
+        .where(UploadedFile.company_id == company.id)
+        .order_by(col(UploadedFile.created_at).desc())
+    ).all()
+    return UploadedFilesPublic(data=files, count=len(files))
+
+
+@router.post("/{id}/reserve", response_model=ReserveRecommendationPublic)
+def recommend_company_reserve(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    params: ReserveRequest,
+) -> Any:
+    """
+    Recommend follow-on reserve sizing for an invested company from its
+    latest performance, expected dilution, and the fund's concentration
+    limit.
+    """
+    company = get_company_for_user(session, current_user, id)
+    if company.pipeline_stage != PipelineStage.INVESTED:
+        raise HTTPException(
+            status_code=409, detail="Company is not an invested deal"
+        )
+    return reserves.recommend_reserve(session, company, params)
+
+
+@router.get("/{id}/analyses", response_model=AnalysesPublic)
+def read_company_analyses(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Any:
+    """
+    Retrieve the company's evaluation history, newest version first. Each
+    analysis links its predecessor via ``supersedes_id``.
+    """
+    company = get_company_for_user(session, current_user, id)
+    analyses = session.exec(
+        select(Analysis)
+        .where(Analysis.company_id == company.id)
+        .order_by(col(Analysis.version).desc(), col(Analysis.created_at).desc())
+    ).all()
+    return AnalysesPublic(data=analyses, count=len(analyses))
+
+
+@router.get("/{id}/metrics/history", response_model=MetricHistoryPublic)
+def read_company_metric_history(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    metric: str | None = None,
+) -> Any:
+    """
+    How each metric (including the overall score) evolved across the
+    company's completed analysis runs, oldest run first. ``metric``
+    restricts the response to one metric by name.
+    """
+    company = get_company_for_user(session, current_user, id)
+    analyses = session.exec(
+        select(Analysis)
+        .where(
+            Analysis.company_id == company.id,
+            Analysis.status == AnalysisStatus.COMPLETED,
+        )
+        .order_by(col(Analysis.version), col(Analysis.completed_at))
+    ).all()
+    metrics: dict[str, list[MetricHistoryPoint]] = {}
+    for analysis in analyses:
+        for name, value in analysis.results.get("scores", {}).items():
+            if metric is not None and name != metric:
+                continue
+            if not isinstance(value, int | float):
+                continue
+            metrics.setdefault(name, []).append(
+                MetricHistoryPoint(
+                    analysis_id=analysis.id,
+                    version=analysis.version,
+                    completed_at=analysis.completed_at,
+                    value=float(value),
+                )
+            )
+    return MetricHistoryPublic(company_id=company.id, metrics=metrics)
+
+
+@router.get("/{id}", response_model=CompanyPublic)
+def read_company(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    request: Request,
+    response: Response,
+) -> Any:
+    """
+    Get company by ID. Returns an ETag and honors ``If-None-Match``.
+    """
+    company = get_company_for_user(session, current_user, id)
+    etag = compute_etag(CompanyPublic.model_validate(company))
+    if is_not_modified(request, etag):
+        return Response(status_code=304, headers={"ETag": etag})
+    response.headers["ETag"] = etag
+    return company
@@ -0,0 +1,49 @@
+import uuid
+
+from fastapi import APIRouter, HTTPException, Response
+
+from app.api.deps import SessionDep
+from app.api.routes.analysis import build_report_response
+from app.models import Analysis, UploadedFile, UploadStatus
+from app.services import storage
+from app.services.downloads import verify_download_token
+
+router = APIRouter(prefix="/downloads", tags=["downloads"])
+
+
+@router.get("/{token}")
+def download_resource(session: SessionDep, token: str) -> Response:
+    """
+    Serve the report or file a signed link points at.
+
+    Deliberately unauthenticated: access control happened when the link was
+    issued, and the signature plus expiry gate access here.
+    """
+    claims = verify_download_token(token)
+    if not claims:
+        raise HTTPException(
+            status_code=403, detail="Invalid or expired download link"
+        )
+    resource_id = uuid.UUID(claims["sub"])
+    if claims["kind"] == "report":
+        analysis = session.get(Analysis, resource_id)
+        if not analysis:
+            raise HTTPException(status_code=404, detail="Analysis not found")
+        return build_report_response(
+            session, analysis, claims.get("format", "pdf"), claims.get("lang", "en")
+        )
+    if claims["kind"] == "file":
+        upload = session.get(UploadedFile, resource_id)
+        if not upload:
+            raise HTTPException(status_code=404, detail="Upload not found")
+        # A link issued before the scan finished must not serve the file
+        if upload.status == UploadStatus.INFECTED:
+            raise HTTPException(status_code=403, detail="File is quarantined")
+        return Response(
+            content=storage.read_file(upload.storage_path),
+            media_type=upload.content_type,
+            headers={
+                "Content-Disposition": f'attachment; filename="{upload.filename}"'
+            },
+        )
+    raise HTTPException(status_code=404, detail="Unknown download kind")
@@ -0,0 +1,51 @@
+from typing import Any
+
+from fastapi import APIRouter, Depends
+from sqlmodel import col, select
+
+from app.api.deps import SessionDep, get_current_active_superuser
+from app.models import (
+    ExitComp,
+    ExitCompsImport,
+    ExitCompsPublic,
+    ExitType,
+    Message,
+)
+
+router = APIRouter(prefix="/exits", tags=["exits"])
+
+
+@router.get("/", response_model=ExitCompsPublic)
+def read_exit_comps(
+    session: SessionDep,
+    industry: str | None = None,
+    exit_type: ExitType | None = None,
+) -> Any:
+    """
+    Retrieve comparable exits, newest first, optionally filtered by
+    industry or exit type.
+    """
+    statement = select(ExitComp)
+    if industry is not None:
+        statement = statement.where(col(ExitComp.industry).ilike(industry))
+    if exit_type is not None:
+        statement = statement.where(ExitComp.exit_type == exit_type)
+    comps = session.exec(
+        statement.order_by(col(ExitComp.exited_on).desc())
+    ).all()
+    return ExitCompsPublic(data=comps, count=len(comps))
+
+
+@router.post(
+    "/import",
+    dependencies=[Depends(get_current_active_superuser)],
+    response_model=Message,
+)
+def import_exit_comps(*, session: SessionDep, import_in: ExitCompsImport) -> Any:
+    """
+    Import comparable exits into the dataset. Superusers only.
+    """
+    for row in import_in.rows:
+        session.add(ExitComp.model_validate(row))
+    session.commit()
+    return Message(message=f"Imported {len(import_in.rows)} comparable exits")
@@ -0,0 +1,107 @@
+import uuid
+from typing import Any
+
+from fastapi import APIRouter, HTTPException
+from sqlmodel import col, select
+
+from app.api.deps import CurrentUser, SessionDep
+from app.api.routes.companies import get_company_for_user
+from app.models import (
+    Founder,
+    FounderCreate,
+    FounderPublic,
+    FoundersPublic,
+    FounderUpdate,
+    Message,
+)
+
+router = APIRouter(prefix="/companies/{company_id}/founders", tags=["founders"])
+
+
+def _get_founder(
+    session: SessionDep,
+    current_user: CurrentUser,
+    company_id: uuid.UUID,
+    id: uuid.UUID,
+) -> Founder:
+    get_company_for_user(session, current_user, company_id)
+    founder = session.get(Founder, id)
+    if not founder or founder.company_id != company_id:
+        raise HTTPException(status_code=404, detail="Founder not found")
+    return founder
+
+
+@router.get("/", response_model=FoundersPublic)
+def read_founders(
+    session: SessionDep, current_user: CurrentUser, company_id: uuid.UUID
+) -> Any:
+    """
+    Retrieve a company's founders.
+    """
+    get_company_for_user(session, current_user, company_id)
+    founders = session.exec(
+        select(Founder)
+        .where(Founder.company_id == company_id)
+        .order_by(col(Founder.created_at))
+    ).all()
+    return FoundersPublic(data=founders, count=len(founders))
+
+
+@router.post("/", response_model=FounderPublic)
+def create_founder(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    company_id: uuid.UUID,
+    founder_in: FounderCreate,
+) -> Any:
+    """
+    Add a founder to a company. Their LinkedIn profile is enriched during
+    the next analysis run.
+    """
+    company = get_company_for_user(session, current_user, company_id)
+    founder = Founder.model_validate(founder_in, update={"company_id": company.id})
+    session.add(founder)
+    session.commit()
+    session.refresh(founder)
+    return founder
+
+
+@router.patch("/{id}", response_model=FounderPublic)
+def update_founder(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    company_id: uuid.UUID,
+    id: uuid.UUID,
+    founder_in: FounderUpdate,
+) -> Any:
+    """
+    Update a founder. Changing the LinkedIn URL clears the enriched
+    profile so it is fetched again.
+    """
+    founder = _get_founder(session, current_user, company_id, id)
+    update_dict = founder_in.model_dump(exclude_unset=True)
+    if "linkedin_url" in update_dict:
+        founder.profile = {}
+    founder.sqlmodel_update(update_dict)
+    session.add(founder)
+    session.commit()
+    session.refresh(founder)
+    return founder
+
+
+@router.delete("/{id}")
+def delete_founder(
+    session: SessionDep,
+    current_user: CurrentUser,
+    company_id: uuid.UUID,
+    id: uuid.UUID,
+) -> Message:
+    """
+    Remove a founder from a company.
+    """
+    founder = _get_founder(session, current_user, company_id, id)
+    session.delete(founder)
+    session.commit()
+    return Message(message="Founder deleted")
@@ -0,0 +1,107 @@
+import uuid
+from typing import Any
+
+from fastapi import APIRouter, HTTPException
+from sqlmodel import col, select
+
+from app.api.deps import CurrentUser, SessionDep
+from app.api.routes.companies import get_company_for_user
+from app.models import (
+    FundingRound,
+    FundingRoundCreate,
+    FundingRoundPublic,
+    FundingRoundsPublic,
+    FundingRoundUpdate,
+    Message,
+)
+
+router = APIRouter(
+    prefix="/companies/{company_id}/funding-rounds", tags=["funding-rounds"]
+)
+
+
+def _get_round(
+    session: SessionDep,
+    current_user: CurrentUser,
+    company_id: uuid.UUID,
+    id: uuid.UUID,
+) -> FundingRound:
+    get_company_for_user(session, current_user, company_id)
+    funding_round = session.get(FundingRound, id)
+    if not funding_round or funding_round.company_id != company_id:
+        raise HTTPException(status_code=404, detail="Funding round not found")
+    return funding_round
+
+
+@router.get("/", response_model=FundingRoundsPublic)
+def read_funding_rounds(
+    session: SessionDep, current_user: CurrentUser, company_id: uuid.UUID
+) -> Any:
+    """
+    Retrieve a company's funding rounds, most recent first.
+    """
+    get_company_for_user(session, current_user, company_id)
+    rounds = session.exec(
+        select(FundingRound)
+        .where(FundingRound.company_id == company_id)
+        .order_by(col(FundingRound.announced_on).desc())
+    ).all()
+    return FundingRoundsPublic(data=rounds, count=len(rounds))
+
+
+@router.post("/", response_model=FundingRoundPublic)
+def create_funding_round(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    company_id: uuid.UUID,
+    round_in: FundingRoundCreate,
+) -> Any:
+    """
+    Record a funding round for a company.
+    """
+    company = get_company_for_user(session, current_user, company_id)
+    funding_round = FundingRound.model_validate(
+        round_in, update={"company_id": company.id}
+    )
+    session.add(funding_round)
+    session.commit()
+    session.refresh(funding_round)
+    return funding_round
+
+
+@router.patch("/{id}", response_model=FundingRoundPublic)
+def update_funding_round(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    company_id: uuid.UUID,
+    id: uuid.UUID,
+    round_in: FundingRoundUpdate,
+) -> Any:
+    """
+    Update a funding round.
+    """
+    funding_round = _get_round(session, current_user, company_id, id)
+    update_dict = round_in.model_dump(exclude_unset=True)
+    funding_round.sqlmodel_update(update_dict)
+    session.add(funding_round)
+    session.commit()
+    session.refresh(funding_round)
+    return funding_round
+
+
+@router.delete("/{id}")
+def delete_funding_round(
+    session: SessionDep,
+    current_user: CurrentUser,
+    company_id: uuid.UUID,
+    id: uuid.UUID,
+) -> Message:
+    """
+    Delete a funding round.
+    """
+    funding_round = _get_round(session, current_user, company_id, id)
+    session.delete(funding_round)
+    session.commit()
+    return Message(message="Funding round deleted")
@@ -11,6 +11,7 @@ from app.core import security
 from app.core.config import settings
 from app.core.security import get_password_hash
 from app.models import Message, NewPassword, Token, UserPublic
+from app.services import audit
 from app.utils import (
     generate_password_reset_token,
     generate_reset_password_email,
@@ -32,9 +33,15 @@ def login_access_token(
         session=session, email=form_data.username, password=form_data.password
     )
     if not user:
+        audit.emit("auth.login_failed", data={"email": form_data.username})
         raise HTTPException(status_code=400, detail="Incorrect email or password")
     elif not user.is_active:
         raise HTTPException(status_code=400, detail="Inactive user")
+    audit.emit(
+        "auth.login",
+        actor_id=user.id,
+        organization_id=user.organization_id,
+    )
     access_token_expires = timedelta(minutes=settings.ACCESS_TOKEN_EXPIRE_MINUTES)
     return Token(
         access_token=security.create_access_token(
@@ -0,0 +1,437 @@
+import ipaddress
+from typing import Any
+
+from fastapi import APIRouter, File, HTTPException, UploadFile
+
+from app.api.deps import CurrentUser, SessionDep
+from app.core.config import settings
+from app.models import (
+    BrandingConfig,
+    CurrencyConfig,
+    CustomMetricsConfig,
+    DigestConfig,
+    EmbeddingsConfig,
+    IpAllowlistConfig,
+    NotionConfig,
+    Organization,
+    OrganizationPublic,
+    RetentionConfig,
+    RubricConfig,
+    SlackConfig,
+    UploadedFilesPublic,
+    UserRole,
+)
+from app.services import privacy, retention, storage
+from app.services.analysis import get_custom_metrics_config, get_rubric_config
+from app.services.digest import get_digest_config
+from app.services.embeddings import get_embeddings_config
+from app.services.fx import get_currency_config
+from app.services.ip_allowlist import get_ip_allowlist_config
+from app.services.notion import get_notion_config
+from app.services.reports import get_branding_config
+from app.services.retention import get_retention_config
+from app.services.slack import get_slack_config
+
+router = APIRouter(prefix="/organizations", tags=["organizations"])
+
+
+def get_own_organization(
+    session: SessionDep, current_user: CurrentUser
+) -> Organization:
+    if not current_user.organization_id:
+        raise HTTPException(status_code=400, detail="User has no organization")
+    organization = session.get(Organization, current_user.organization_id)
+    if not organization:
+        raise HTTPException(status_code=404, detail="Organization not found")
+    return organization
+
+
+def require_org_admin(current_user: CurrentUser) -> None:
+    if not current_user.is_superuser and current_user.role != UserRole.ADMIN:
+        raise HTTPException(
+            status_code=403, detail="The user doesn't have enough privileges"
+        )
+
+
+@router.get("/me", response_model=OrganizationPublic)
+def read_own_organization(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Get the caller's organization.
+    """
+    return get_own_organization(session, current_user)
+
+
+@router.get("/me/export")
+def export_own_organization(
+    session: SessionDep, current_user: CurrentUser
+) -> Any:
+    """
+    Export everything held for the organization as one machine-readable
+    JSON document, for data-portability requests.
+    """
+    require_org_admin(current_user)
+    organization = get_own_organization(session, current_user)
+    return privacy.export_organization(session, organization)
+
+
+@router.get("/me/branding", response_model=BrandingConfig)
+def read_branding_config(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Get the organization's report branding settings.
+    """
+    organization = get_own_organization(session, current_user)
+    return get_branding_config(organization)
+
+
+@router.put("/me/branding", response_model=BrandingConfig)
+def update_branding_config(
+    *, session: SessionDep, current_user: CurrentUser, config_in: BrandingConfig
+) -> Any:
+    """
+    Configure brand colors and footer applied to generated reports. Admins
+    only. The logo is managed via its own upload endpoint.
+    """
+    require_org_admin(current_user)
+    organization = get_own_organization(session, current_user)
+    config = config_in.model_dump()
+    config["logo_path"] = get_branding_config(organization).logo_path
+    organization.settings = {**organization.settings, "branding": config}
+    session.add(organization)
+    session.commit()
+    session.refresh(organization)
+    return get_branding_config(organization)
+
+
+@router.post("/me/branding/logo", response_model=BrandingConfig)
+async def upload_branding_logo(
+    *, session: SessionDep, current_user: CurrentUser, file: UploadFile = File(...)
+) -> Any:
+    """
+    Upload the logo shown on generated reports. Admins only.
+    """
+    require_org_admin(current_user)
+    if not (file.content_type or "").startswith("image/"):
+        raise HTTPException(status_code=422, detail="Logo must be an image")
+    organization = get_own_organization(session, current_user)
+    config = get_branding_config(organization)
+    content = await file.read()
+    if len(content) > settings.MAX_UPLOAD_SIZE:
+        raise HTTPException(status_code=413, detail="File is too large")
+    # Keep the current logo until the replacement is stored and
+    # committed; a rejected or failed upload must not orphan it.
+    old_path = config.logo_path
+    config.logo_path = storage.save_upload(file.filename or "logo", content)
+    organization.settings = {
+        **organization.settings,
+        "branding": config.model_dump(),
+    }
+    session.add(organization)
+    session.commit()
+    session.refresh(organization)
+    if old_path:
+        storage.delete_file(old_path)
+    return get_branding_config(organization)
+
+
+@router.get("/me/digest", response_model=DigestConfig)
+def read_digest_config(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Get the organization's weekly digest settings.
+    """
+    organization = get_own_organization(session, current_user)
+    return get_digest_config(organization)
+
+
+@router.put("/me/digest", response_model=DigestConfig)
+def update_digest_config(
+    *, session: SessionDep, current_user: CurrentUser, config_in: DigestConfig
+) -> Any:
+    """
+    Configure the weekly digest email. Admins only.
+    """
+    require_org_admin(current_user)
+    organization = get_own_organization(session, current_user)
+    organization.settings = {
+        **organization.settings,
+        "digest": config_in.model_dump(),
+    }
+    session.add(organization)
+    session.commit()
+    session.refresh(organization)
+    return get_digest_config(organization)
+
+
+@router.get("/me/embeddings", response_model=EmbeddingsConfig)
+def read_embeddings_config(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Get the organization's embeddings provider selection.
+    """
+    organization = get_own_organization(session, current_user)
+    return get_embeddings_config(organization)
+
+
+@router.put("/me/embeddings", response_model=EmbeddingsConfig)
+def update_embeddings_config(
+    *, session: SessionDep, current_user: CurrentUser, config_in: EmbeddingsConfig
+) -> Any:
+    """
+    Select the embeddings provider and model for the organization. Admins only.
+    """
+    require_org_admin(current_user)
+    if config_in.provider not in ("openai", "local"):
+        raise HTTPException(status_code=422, detail="Unknown embeddings provider")
+    organization = get_own_organization(session, current_user)
+    organization.settings = {
+        **organization.settings,
+        "embeddings": config_in.model_dump(),
+    }
+    session.add(organization)
+    session.commit()
+    session.refresh(organization)
+    return get_embeddings_config(organization)
+
+
+@router.get("/me/notion", response_model=NotionConfig)
+def read_notion_config(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Get the organization's Notion integration settings.
+    """
+    organization = get_own_organization(session, current_user)
+    return get_notion_config(organization)
+
+
+@router.put("/me/notion", response_model=NotionConfig)
+def update_notion_config(
+    *, session: SessionDep, current_user: CurrentUser, config_in: NotionConfig
+) -> Any:
+    """
+    Configure the Notion database memos are exported to. Admins only.
+    """
+    require_org_admin(current_user)
+    organization = get_own_organization(session, current_user)
+    organization.settings = {
+        **organization.settings,
+        "notion": config_in.model_dump(),
+    }
+    session.add(organization)
+    session.commit()
+    session.refresh(organization)
+    return get_notion_config(organization)
+
+
+@router.get("/me/currency", response_model=CurrencyConfig)
+def read_currency_config(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Get the organization's base currency settings.
+    """
+    organization = get_own_organization(session, current_user)
+    return get_currency_config(organization)
+
+
+@router.put("/me/currency", response_model=CurrencyConfig)
+def update_currency_config(
+    *, session: SessionDep, current_user: CurrentUser, config_in: CurrencyConfig
+) -> Any:
+    """
+    Configure the currency extracted financials are normalized to.
+    Admins only.
+    """
+    require_org_admin(current_user)
+    organization = get_own_organization(session, current_user)
+    organization.settings = {
+        **organization.settings,
+        "currency": config_in.model_dump(),
+    }
+    session.add(organization)
+    session.commit()
+    session.refresh(organization)
+    return get_currency_config(organization)
+
+
+@router.get("/me/rubric", response_model=RubricConfig)
+def read_rubric_config(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Get the organization's scoring rubric.
+    """
+    organization = get_own_organization(session, current_user)
+    return get_rubric_config(organization)
+
+
+@router.put("/me/rubric", response_model=RubricConfig)
+def update_rubric_config(
+    *, session: SessionDep, current_user: CurrentUser, config_in: RubricConfig
+) -> Any:
+    """
+    Configure the metric weights the overall score is computed from.
+    Admins only.
+    """
+    require_org_admin(current_user)
+    if any(weight < 0 for weight in config_in.weights.values()):
+        raise HTTPException(
+            status_code=422, detail="Weights must not be negative"
+        )
+    organization = get_own_organization(session, current_user)
+    organization.settings = {
+        **organization.settings,
+        "rubric": config_in.model_dump(),
+    }
+    session.add(organization)
+    session.commit()
+    session.refresh(organization)
+    return get_rubric_config(organization)
+
+
+@router.get("/me/custom-metrics", response_model=CustomMetricsConfig)
+def read_custom_metrics_config(
+    session: SessionDep, current_user: CurrentUser
+) -> Any:
+    """
+    Get the organization's custom metric definitions.
+    """
+    organization = get_own_organization(session, current_user)
+    return get_custom_metrics_config(organization)
+
+
+@router.put("/me/custom-metrics", response_model=CustomMetricsConfig)
+def update_custom_metrics_config(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    config_in: CustomMetricsConfig,
+) -> Any:
+    """
+    Define additional metrics the analysis engine scores alongside the
+    built-in ones. Admins only.
+    """
+    require_org_admin(current_user)
+    names = [metric.name.lower() for metric in config_in.metrics]
+    if len(names) != len(set(names)):
+        raise HTTPException(
+            status_code=422, detail="Metric names must be unique"
+        )
+    organization = get_own_organization(session, current_user)
+    organization.settings = {
+        **organization.settings,
+        "custom_metrics": config_in.model_dump(),
+    }
+    session.add(organization)
+    session.commit()
+    session.refresh(organization)
+    return get_custom_metrics_config(organization)
+
+
+@router.get("/me/ip-allowlist", response_model=IpAllowlistConfig)
+def read_ip_allowlist_config(
+    session: SessionDep, current_user: CurrentUser
+) -> Any:
+    """
+    Get the organization's IP allowlist.
+    """
+    organization = get_own_organization(session, current_user)
+    return get_ip_allowlist_config(organization)
+
+
+@router.put("/me/ip-allowlist", response_model=IpAllowlistConfig)
+def update_ip_allowlist_config(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    config_in: IpAllowlistConfig,
+) -> Any:
+    """
+    Restrict API access to the given CIDR ranges. Admins only — and mind
+    that a range excluding your own network locks you out too.
+    """
+    require_org_admin(current_user)
+    for cidr in config_in.cidrs:
+        try:
+            ipaddress.ip_network(cidr, strict=False)
+        except ValueError:
+            raise HTTPException(
+                status_code=422, detail=f"Invalid CIDR range: {cidr}"
+            )
+    if config_in.enabled and not config_in.cidrs:
+        raise HTTPException(
+            status_code=422,
+            detail="An enabled allowlist needs at least one CIDR range",
+        )
+    organization = get_own_organization(session, current_user)
+    organization.settings = {
+        **organization.settings,
+        "ip_allowlist": config_in.model_dump(),
+    }
+    session.add(organization)
+    session.commit()
+    session.refresh(organization)
+    return get_ip_allowlist_config(organization)
+
+
+@router.get("/me/retention", response_model=RetentionConfig)
+def read_retention_config(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Get the organization's data retention policy.
+    """
+    organization = get_own_organization(session, current_user)
+    return get_retention_config(organization)
+
+
+@router.put("/me/retention", response_model=RetentionConfig)
+def update_retention_config(
+    *, session: SessionDep, current_user: CurrentUser, config_in: RetentionConfig
+) -> Any:
+    """
+    Configure how long uploads are retained before the purge job removes
+    them. Admins only.
+    """
+    require_org_admin(current_user)
+    organization = get_own_organization(session, current_user)
+    organization.settings = {
+        **organization.settings,
+        "retention": config_in.model_dump(),
+    }
+    session.add(organization)
+    session.commit()
+    session.refresh(organization)
+    return get_retention_config(organization)
+
+
+@router.get("/me/retention/preview", response_model=UploadedFilesPublic)
+def preview_retention_purge(
+    session: SessionDep, current_user: CurrentUser
+) -> Any:
+    """
+    Dry run of the purge job: the uploads the current policy would delete,
+    without deleting anything. Admins only.
+    """
+    require_org_admin(current_user)
+    organization = get_own_organization(session, current_user)
+    uploads = retention.eligible_uploads(session, organization)
+    return UploadedFilesPublic(data=uploads, count=len(uploads))
+
+
+@router.get("/me/slack", response_model=SlackConfig)
+def read_slack_config(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Get the organization's Slack notification settings.
+    """
+    organization = get_own_organization(session, current_user)
+    return get_slack_config(organization)
+
+
+@router.put("/me/slack", response_model=SlackConfig)
+def update_slack_config(
+    *, session: SessionDep, current_user: CurrentUser, config_in: SlackConfig
+) -> Any:
+    """
+    Configure where completed analyses are posted in Slack. Admins only.
+    """
+    require_org_admin(current_user)
+    organization = get_own_organization(session, current_user)
+    organization.settings = {
+        **organization.settings,
+        "slack": config_in.model_dump(),
+    }
+    session.add(organization)
+    session.commit()
+    session.refresh(organization)
+    return get_slack_config(organization)
@@ -0,0 +1,37 @@
+from typing import Any
+
+from fastapi import APIRouter
+from sqlmodel import col, select
+
+from app.api.deps import CurrentUser, SessionDep
+from app.models import Company, PipelineColumn, PipelinePublic, PipelineStage
+
+router = APIRouter(prefix="/pipeline", tags=["pipeline"])
+
+
+@router.get("/", response_model=PipelinePublic)
+def read_pipeline(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Group the caller's active companies by pipeline stage for a kanban view.
+    Columns come back in process order, including empty ones.
+    """
+    statement = select(Company).where(
+        col(Company.deleted_at).is_(None),
+        Company.archived == False,  # noqa: E712
+    )
+    if not current_user.is_superuser:
+        statement = statement.where(Company.owner_id == current_user.id)
+    companies = session.exec(
+        statement.order_by(col(Company.created_at).desc())
+    ).all()
+    by_stage: dict[PipelineStage, list[Company]] = {
+        stage: [] for stage in PipelineStage
+    }
+    for company in companies:
+        by_stage[company.pipeline_stage].append(company)
+    return PipelinePublic(
+        columns=[
+            PipelineColumn(stage=stage, companies=by_stage[stage])
+            for stage in PipelineStage
+        ]
+    )
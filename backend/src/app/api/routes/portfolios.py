@@ -0,0 +1,241 @@
+import uuid
+from typing import Any
+
+from fastapi import APIRouter, HTTPException
+from sqlmodel import col, select
+
+from app.api.deps import CurrentUser, SessionDep
+from app.api.routes.companies import get_company_for_user
+from app.models import (
+    AllocationPublic,
+    AllocationRequest,
+    Analysis,
+    AnalysisStatus,
+    Company,
+    Message,
+    PipelineStage,
+    Portfolio,
+    PortfolioAnalyticsPublic,
+    PortfolioCompany,
+    PortfolioCreate,
+    PortfolioPublic,
+    PortfoliosPublic,
+    PortfolioUpdate,
+)
+from app.services import allocation
+
+router = APIRouter(prefix="/portfolios", tags=["portfolios"])
+
+
+def get_portfolio_for_user(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Portfolio:
+    portfolio = session.get(Portfolio, id)
+    if not portfolio:
+        raise HTTPException(status_code=404, detail="Portfolio not found")
+    if not current_user.is_superuser and (portfolio.owner_id != current_user.id):
+        raise HTTPException(status_code=400, detail="Not enough permissions")
+    return portfolio
+
+
+def _portfolio_companies(
+    session: SessionDep, portfolio: Portfolio
+) -> list[Company]:
+    return list(
+        session.exec(
+            select(Company)
+            .join(PortfolioCompany, PortfolioCompany.company_id == Company.id)  # type: ignore[arg-type]
+            .where(
+                PortfolioCompany.portfolio_id == portfolio.id,
+                col(Company.deleted_at).is_(None),
+            )
+        ).all()
+    )
+
+
+@router.get("/", response_model=PortfoliosPublic)
+def read_portfolios(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Retrieve the caller's portfolios.
+    """
+    portfolios = session.exec(
+        select(Portfolio)
+        .where(Portfolio.owner_id == current_user.id)
+        .order_by(col(Portfolio.created_at).desc())
+    ).all()
+    return PortfoliosPublic(data=portfolios, count=len(portfolios))
+
+
+@router.post("/", response_model=PortfolioPublic)
+def create_portfolio(
+    *, session: SessionDep, current_user: CurrentUser, portfolio_in: PortfolioCreate
+) -> Any:
+    """
+    Create a portfolio grouping companies.
+    """
+    portfolio = Portfolio.model_validate(
+        portfolio_in, update={"owner_id": current_user.id}
+    )
+    session.add(portfolio)
+    session.commit()
+    session.refresh(portfolio)
+    return portfolio
+
+
+@router.post("/optimize", response_model=AllocationPublic)
+def optimize_allocation(
+    *, session: SessionDep, current_user: CurrentUser, params: AllocationRequest
+) -> Any:
+    """
+    Recommend how to allocate a fund across the caller's current candidate
+    deals (not yet invested or passed), best analysis scores first,
+    respecting the target sector and stage mix.
+    """
+    if params.min_check_usd > params.max_check_usd:
+        raise HTTPException(
+            status_code=422,
+            detail="min_check_usd must not exceed max_check_usd",
+        )
+    candidates = list(
+        session.exec(
+            select(Company).where(
+                Company.owner_id == current_user.id,
+                col(Company.deleted_at).is_(None),
+                col(Company.pipeline_stage).notin_(
+                    [PipelineStage.INVESTED, PipelineStage.PASSED]
+                ),
+            )
+        ).all()
+    )
+    return allocation.optimize(session, candidates, params)
+
+
+@router.patch("/{id}", response_model=PortfolioPublic)
+def update_portfolio(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    portfolio_in: PortfolioUpdate,
+) -> Any:
+    """
+    Update a portfolio.
+    """
+    portfolio = get_portfolio_for_user(session, current_user, id)
+    portfolio.sqlmodel_update(portfolio_in.model_dump(exclude_unset=True))
+    session.add(portfolio)
+    session.commit()
+    session.refresh(portfolio)
+    return portfolio
+
+
+@router.delete("/{id}")
+def delete_portfolio(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Message:
+    """
+    Delete a portfolio; the companies in it are untouched.
+    """
+    portfolio = get_portfolio_for_user(session, current_user, id)
+    session.delete(portfolio)
+    session.commit()
+    return Message(message="Portfolio deleted")
+
+
+@router.put("/{id}/companies/{company_id}", response_model=Message)
+def add_portfolio_company(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    company_id: uuid.UUID,
+) -> Any:
+    """
+    Add a company to a portfolio. Adding twice is a no-op.
+    """
+    portfolio = get_portfolio_for_user(session, current_user, id)
+    company = get_company_for_user(session, current_user, company_id)
+    link = session.get(PortfolioCompany, (portfolio.id, company.id))
+    if not link:
+        session.add(
+            PortfolioCompany(portfolio_id=portfolio.id, company_id=company.id)
+        )
+        session.commit()
+    return Message(message="Company added to portfolio")
+
+
+@router.delete("/{id}/companies/{company_id}", response_model=Message)
+def remove_portfolio_company(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    company_id: uuid.UUID,
+) -> Any:
+    """
+    Remove a company from a portfolio.
+    """
+    portfolio = get_portfolio_for_user(session, current_user, id)
+    company = get_company_for_user(session, current_user, company_id)
+    link = session.get(PortfolioCompany, (portfolio.id, company.id))
+    if not link:
+        raise HTTPException(
+            status_code=404, detail="Company is not in this portfolio"
+        )
+    session.delete(link)
+    session.commit()
+    return Message(message="Company removed from portfolio")
+
+
+@router.get("/{id}/analytics", response_model=PortfolioAnalyticsPublic)
+def read_portfolio_analytics(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Any:
+    """
+    Aggregate scores, sector exposure, stage distribution, and risk
+    concentration across the portfolio's companies.
+    """
+    portfolio = get_portfolio_for_user(session, current_user, id)
+    companies = _portfolio_companies(session, portfolio)
+    scores = []
+    low_runway = 0
+    for company in companies:
+        latest = session.exec(
+            select(Analysis)
+            .where(
+                Analysis.company_id == company.id,
+                Analysis.status == AnalysisStatus.COMPLETED,
+            )
+            .order_by(col(Analysis.completed_at).desc())
+        ).first()
+        if latest and latest.score is not None:
+            scores.append(latest.score)
+        if (
+            latest
+            and latest.runway_months is not None
+            and latest.runway_months < 6
+        ):
+            low_runway += 1
+    sector_exposure: dict[str, int] = {}
+    stage_distribution: dict[str, int] = {}
+    for company in companies:
+        sector = company.industry or "unknown"
+        sector_exposure[sector] = sector_exposure.get(sector, 0) + 1
+        stage = company.stage.value if company.stage else "unknown"
+        stage_distribution[stage] = stage_distribution.get(stage, 0) + 1
+    top_sector = max(sector_exposure, key=lambda s: sector_exposure[s], default=None)
+    return PortfolioAnalyticsPublic(
+        company_count=len(companies),
+        average_score=(
+            round(sum(scores) / len(scores), 2) if scores else None
+        ),
+        sector_exposure=sector_exposure,
+        stage_distribution=stage_distribution,
+        risk_concentration={
+            "top_sector": top_sector,
+            "top_sector_share": (
+                round(sector_exposure[top_sector] / len(companies), 2)
+                if top_sector and companies
+                else None
+            ),
+            "low_runway_companies": low_runway,
+        },
+    )
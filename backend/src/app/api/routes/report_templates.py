@@ -0,0 +1,162 @@
+import uuid
+from typing import Any
+
+from fastapi import APIRouter, HTTPException
+from sqlmodel import func, select
+
+from app.api.deps import CurrentUser, SessionDep
+from app.api.routes.organizations import require_org_admin
+from app.models import (
+    REPORT_SECTION_KEYS,
+    Message,
+    ReportTemplate,
+    ReportTemplateCreate,
+    ReportTemplatePublic,
+    ReportTemplatesPublic,
+    ReportTemplateUpdate,
+)
+
+router = APIRouter(prefix="/report-templates", tags=["report-templates"])
+
+
+def _validate_sections(sections: list[dict[str, Any]]) -> None:
+    unknown = [
+        s.get("key") for s in sections if s.get("key") not in REPORT_SECTION_KEYS
+    ]
+    if unknown:
+        raise HTTPException(
+            status_code=422,
+            detail=f"Unknown section keys: {', '.join(str(k) for k in unknown)}",
+        )
+
+
+def get_template_for_user(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> ReportTemplate:
+    template = session.get(ReportTemplate, id)
+    if not template:
+        raise HTTPException(status_code=404, detail="Report template not found")
+    if not current_user.is_superuser and (
+        template.organization_id != current_user.organization_id
+    ):
+        raise HTTPException(status_code=400, detail="Not enough permissions")
+    return template
+
+
+@router.get("/", response_model=ReportTemplatesPublic)
+def read_report_templates(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Retrieve the organization's report templates.
+    """
+    if current_user.organization_id is None:
+        raise HTTPException(
+            status_code=400, detail="User does not belong to an organization"
+        )
+    count_statement = (
+        select(func.count())
+        .select_from(ReportTemplate)
+        .where(ReportTemplate.organization_id == current_user.organization_id)
+    )
+    count = session.exec(count_statement).one()
+    statement = select(ReportTemplate).where(
+        ReportTemplate.organization_id == current_user.organization_id
+    )
+    templates = session.exec(statement).all()
+    return ReportTemplatesPublic(data=templates, count=count)
+
+
+@router.post("/", response_model=ReportTemplatePublic)
+def create_report_template(
+    *, session: SessionDep, current_user: CurrentUser, template_in: ReportTemplateCreate
+) -> Any:
+    """
+    Create a report template for the caller's organization.
+    """
+    require_org_admin(current_user)
+    if current_user.organization_id is None:
+        raise HTTPException(
+            status_code=400, detail="User does not belong to an organization"
+        )
+    _validate_sections(template_in.sections)
+    template = ReportTemplate.model_validate(
+        template_in,
+        update={
+            "organization_id": current_user.organization_id,
+            "created_by": current_user.id,
+        },
+    )
+    session.add(template)
+    session.commit()
+    session.refresh(template)
+    return template
+
+
+@router.get("/{id}", response_model=ReportTemplatePublic)
+def read_report_template(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Any:
+    """
+    Get a report template by ID.
+    """
+    return get_template_for_user(session, current_user, id)
+
+
+@router.patch("/{id}", response_model=ReportTemplatePublic)
+def update_report_template(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    template_in: ReportTemplateUpdate,
+) -> Any:
+    """
+    Update a report template.
+    """
+    require_org_admin(current_user)
+    template = get_template_for_user(session, current_user, id)
+    update_dict = template_in.model_dump(exclude_unset=True)
+    if "sections" in update_dict:
+        _validate_sections(update_dict["sections"])
+    template.sqlmodel_update(update_dict)
+    session.add(template)
+    session.commit()
+    session.refresh(template)
+    return template
+
+
+@router.post("/{id}/activate", response_model=ReportTemplatePublic)
+def activate_report_template(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Any:
+    """
+    Make a template the one used for report generation. Only one template
+    per organization is active at a time.
+    """
+    require_org_admin(current_user)
+    template = get_template_for_user(session, current_user, id)
+    statement = select(ReportTemplate).where(
+        ReportTemplate.organization_id == template.organization_id,
+        ReportTemplate.is_active == True,  # noqa: E712
+    )
+    for active in session.exec(statement).all():
+        active.is_active = False
+        session.add(active)
+    template.is_active = True
+    session.add(template)
+    session.commit()
+    session.refresh(template)
+    return template
+
+
+@router.delete("/{id}")
+def delete_report_template(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Message:
+    """
+    Delete a report template.
+    """
+    require_org_admin(current_user)
+    template = get_template_for_user(session, current_user, id)
+    session.delete(template)
+    session.commit()
+    return Message(message="Report template deleted successfully")
@@ -0,0 +1,124 @@
+import uuid
+from typing import Any
+
+from fastapi import APIRouter, HTTPException
+from sqlmodel import col, select
+
+from app.api.deps import CurrentUser, SessionDep
+from app.api.routes.companies import get_company_for_user
+from app.models import (
+    AnalysisSchedule,
+    AnalysisScheduleCreate,
+    AnalysisSchedulePublic,
+    AnalysisSchedulesPublic,
+    AnalysisScheduleUpdate,
+    Company,
+    Message,
+    utcnow,
+)
+from app.services.scheduler import interval_for
+
+router = APIRouter(prefix="/schedules", tags=["schedules"])
+
+
+def get_schedule_for_user(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> AnalysisSchedule:
+    schedule = session.get(AnalysisSchedule, id)
+    if not schedule:
+        raise HTTPException(status_code=404, detail="Schedule not found")
+    company = session.get(Company, schedule.company_id)
+    if not current_user.is_superuser and (
+        not company or company.owner_id != current_user.id
+    ):
+        raise HTTPException(status_code=400, detail="Not enough permissions")
+    return schedule
+
+
+@router.get("/", response_model=AnalysisSchedulesPublic)
+def read_schedules(
+    session: SessionDep,
+    current_user: CurrentUser,
+    company_id: uuid.UUID | None = None,
+) -> Any:
+    """
+    Retrieve re-analysis schedules for the caller's companies.
+    """
+    statement = select(AnalysisSchedule).join(Company)
+    if not current_user.is_superuser:
+        statement = statement.where(Company.owner_id == current_user.id)
+    if company_id is not None:
+        statement = statement.where(AnalysisSchedule.company_id == company_id)
+    schedules = session.exec(
+        statement.order_by(col(AnalysisSchedule.next_run_at))
+    ).all()
+    return AnalysisSchedulesPublic(data=schedules, count=len(schedules))
+
+
+@router.post("/", response_model=AnalysisSchedulePublic)
+def create_schedule(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    schedule_in: AnalysisScheduleCreate,
+) -> Any:
+    """
+    Schedule periodic re-analysis for a company. One schedule per company;
+    the first run happens one interval from now.
+    """
+    company = get_company_for_user(session, current_user, schedule_in.company_id)
+    existing = session.exec(
+        select(AnalysisSchedule).where(AnalysisSchedule.company_id == company.id)
+    ).first()
+    if existing:
+        raise HTTPException(
+            status_code=409, detail="Company already has a schedule"
+        )
+    schedule = AnalysisSchedule.model_validate(
+        schedule_in,
+        update={
+            "created_by": current_user.id,
+            "next_run_at": utcnow() + interval_for(schedule_in.frequency),
+        },
+    )
+    session.add(schedule)
+    session.commit()
+    session.refresh(schedule)
+    return schedule
+
+
+@router.patch("/{id}", response_model=AnalysisSchedulePublic)
+def update_schedule(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    schedule_in: AnalysisScheduleUpdate,
+) -> Any:
+    """
+    Update a schedule's frequency or pause it. Changing the frequency
+    re-anchors the next run one new interval from now.
+    """
+    schedule = get_schedule_for_user(session, current_user, id)
+    update = schedule_in.model_dump(exclude_unset=True)
+    frequency = update.get("frequency")
+    if frequency is not None and frequency != schedule.frequency:
+        update["next_run_at"] = utcnow() + interval_for(frequency)
+    schedule.sqlmodel_update(update)
+    session.add(schedule)
+    session.commit()
+    session.refresh(schedule)
+    return schedule
+
+
+@router.delete("/{id}")
+def delete_schedule(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Message:
+    """
+    Delete a re-analysis schedule.
+    """
+    schedule = get_schedule_for_user(session, current_user, id)
+    session.delete(schedule)
+    session.commit()
+    return Message(message="Schedule deleted")
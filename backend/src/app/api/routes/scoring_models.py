@@ -0,0 +1,127 @@
+import uuid
+from typing import Any
+
+from fastapi import APIRouter, HTTPException
+from sqlmodel import col, select
+
+from app.api.deps import CurrentUser, SessionDep
+from app.api.routes.organizations import require_org_admin
+from app.models import (
+    ScoringModel,
+    ScoringModelCreate,
+    ScoringModelPublic,
+    ScoringModelsPublic,
+)
+from app.services import scoring_model
+
+router = APIRouter(prefix="/scoring-models", tags=["scoring-models"])
+
+
+def _require_organization(current_user: CurrentUser) -> uuid.UUID:
+    if not current_user.organization_id:
+        raise HTTPException(status_code=400, detail="User has no organization")
+    return current_user.organization_id
+
+
+def get_model_for_user(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> ScoringModel:
+    model = session.get(ScoringModel, id)
+    if not model:
+        raise HTTPException(status_code=404, detail="Scoring model not found")
+    if not current_user.is_superuser and (
+        model.organization_id != current_user.organization_id
+    ):
+        raise HTTPException(status_code=400, detail="Not enough permissions")
+    return model
+
+
+@router.get("/", response_model=ScoringModelsPublic)
+def read_scoring_models(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Retrieve the organization's scoring model versions, newest first.
+    """
+    organization_id = _require_organization(current_user)
+    models = session.exec(
+        select(ScoringModel)
+        .where(ScoringModel.organization_id == organization_id)
+        .order_by(col(ScoringModel.version).desc())
+    ).all()
+    return ScoringModelsPublic(data=models, count=len(models))
+
+
+@router.post("/", response_model=ScoringModelPublic)
+def create_scoring_model(
+    *, session: SessionDep, current_user: CurrentUser, model_in: ScoringModelCreate
+) -> Any:
+    """
+    Upload a new scoring model version. The definition is validated before
+    it is stored; versions are immutable. Admins only.
+    """
+    require_org_admin(current_user)
+    organization_id = _require_organization(current_user)
+    try:
+        scoring_model.validate_definition(model_in.definition)
+    except ValueError as e:
+        raise HTTPException(status_code=422, detail=str(e))
+    latest = session.exec(
+        select(ScoringModel)
+        .where(ScoringModel.organization_id == organization_id)
+        .order_by(col(ScoringModel.version).desc())
+    ).first()
+    model = ScoringModel.model_validate(
+        model_in,
+        update={
+            "organization_id": organization_id,
+            "version": latest.version + 1 if latest else 1,
+            "is_active": model_in.activate,
+            "created_by": current_user.id,
+        },
+    )
+    if model_in.activate:
+        for existing in session.exec(
+            select(ScoringModel).where(
+                ScoringModel.organization_id == organization_id,
+                ScoringModel.is_active == True,  # noqa: E712
+            )
+        ).all():
+            existing.is_active = False
+            session.add(existing)
+    session.add(model)
+    session.commit()
+    session.refresh(model)
+    return model
+
+
+@router.post("/{id}/activate", response_model=ScoringModelPublic)
+def activate_scoring_model(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Any:
+    """
+    Make an earlier scoring model version the active one. Admins only.
+    """
+    require_org_admin(current_user)
+    model = get_model_for_user(session, current_user, id)
+    for existing in session.exec(
+        select(ScoringModel).where(
+            ScoringModel.organization_id == model.organization_id,
+            ScoringModel.is_active == True,  # noqa: E712
+        )
+    ).all():
+        existing.is_active = False
+        session.add(existing)
+    model.is_active = True
+    session.add(model)
+    session.commit()
+    session.refresh(model)
+    return model
+
+
+@router.get("/{id}", response_model=ScoringModelPublic)
+def read_scoring_model(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Any:
+    """
+    Get a scoring model version by ID.
+    """
+    return get_model_for_user(session, current_user, id)
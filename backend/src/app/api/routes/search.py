@@ -0,0 +1,20 @@
+from typing import Any
+
+from fastapi import APIRouter, Query
+
+from app.api.deps import CurrentUser, SessionDep
+from app.models import SearchResultsPublic
+from app.services import search as search_service
+
+router = APIRouter(prefix="/search", tags=["search"])
+
+
+@router.get("/", response_model=SearchResultsPublic)
+def search(
+    session: SessionDep, current_user: CurrentUser, q: str = Query(min_length=2)
+) -> Any:
+    """
+    Search company names, descriptions, analysis insights, and extracted
+    document text. Results are grouped by entity type.
+    """
+    return search_service.search(session, current_user, q)
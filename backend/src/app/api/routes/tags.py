@@ -0,0 +1,176 @@
+import uuid
+from typing import Any
+
+from fastapi import APIRouter, HTTPException
+from sqlmodel import col, func, select
+
+from app.api.deps import CurrentUser, SessionDep
+from app.api.routes.analysis import get_analysis_for_user
+from app.api.routes.companies import get_company_for_user
+from app.models import (
+    AnalysisTag,
+    CompanyTag,
+    Message,
+    Tag,
+    TagCreate,
+    TagPublic,
+    TagsPublic,
+)
+
+router = APIRouter(prefix="/tags", tags=["tags"])
+
+
+def get_tag_for_user(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Tag:
+    tag = session.get(Tag, id)
+    if not tag:
+        raise HTTPException(status_code=404, detail="Tag not found")
+    if not current_user.is_superuser and (tag.owner_id != current_user.id):
+        raise HTTPException(status_code=400, detail="Not enough permissions")
+    return tag
+
+
+@router.get("/", response_model=TagsPublic)
+def read_tags(
+    session: SessionDep,
+    current_user: CurrentUser,
+    company_id: uuid.UUID | None = None,
+    analysis_id: uuid.UUID | None = None,
+) -> Any:
+    """
+    Retrieve the caller's tags, alphabetically. ``company_id`` or
+    ``analysis_id`` narrows the list to tags attached to that record.
+    """
+    statement = select(Tag).where(Tag.owner_id == current_user.id)
+    if company_id is not None:
+        statement = statement.where(
+            col(Tag.id).in_(
+                select(CompanyTag.tag_id).where(CompanyTag.company_id == company_id)
+            )
+        )
+    if analysis_id is not None:
+        statement = statement.where(
+            col(Tag.id).in_(
+                select(AnalysisTag.tag_id).where(
+                    AnalysisTag.analysis_id == analysis_id
+                )
+            )
+        )
+    tags = session.exec(statement.order_by(col(Tag.name))).all()
+    return TagsPublic(data=tags, count=len(tags))
+
+
+@router.post("/", response_model=TagPublic)
+def create_tag(
+    *, session: SessionDep, current_user: CurrentUser, tag_in: TagCreate
+) -> Any:
+    """
+    Create a new tag. Names are unique per user.
+    """
+    existing = session.exec(
+        select(Tag).where(
+            Tag.owner_id == current_user.id,
+            func.lower(Tag.name) == tag_in.name.lower(),
+        )
+    ).first()
+    if existing:
+        raise HTTPException(
+            status_code=409, detail="A tag with this name already exists"
+        )
+    tag = Tag.model_validate(tag_in, update={"owner_id": current_user.id})
+    session.add(tag)
+    session.commit()
+    session.refresh(tag)
+    return tag
+
+
+@router.delete("/{id}")
+def delete_tag(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Message:
+    """
+    Delete a tag, detaching it from every company and analysis.
+    """
+    tag = get_tag_for_user(session, current_user, id)
+    session.delete(tag)
+    session.commit()
+    return Message(message="Tag deleted")
+
+
+@router.put("/{id}/companies/{company_id}", response_model=Message)
+def attach_tag_to_company(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    company_id: uuid.UUID,
+) -> Any:
+    """
+    Attach a tag to a company. Attaching twice is a no-op.
+    """
+    tag = get_tag_for_user(session, current_user, id)
+    company = get_company_for_user(session, current_user, company_id)
+    link = session.get(CompanyTag, (company.id, tag.id))
+    if not link:
+        session.add(CompanyTag(company_id=company.id, tag_id=tag.id))
+        session.commit()
+    return Message(message="Tag attached")
+
+
+@router.delete("/{id}/companies/{company_id}", response_model=Message)
+def detach_tag_from_company(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    company_id: uuid.UUID,
+) -> Any:
+    """
+    Detach a tag from a company.
+    """
+    tag = get_tag_for_user(session, current_user, id)
+    company = get_company_for_user(session, current_user, company_id)
+    link = session.get(CompanyTag, (company.id, tag.id))
+    if not link:
+        raise HTTPException(status_code=404, detail="Tag is not attached")
+    session.delete(link)
+    session.commit()
+    return Message(message="Tag detached")
+
+
+@router.put("/{id}/analyses/{analysis_id}", response_model=Message)
+def attach_tag_to_analysis(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    analysis_id: uuid.UUID,
+) -> Any:
+    """
+    Attach a tag to an analysis. Attaching twice is a no-op.
+    """
+    tag = get_tag_for_user(session, current_user, id)
+    analysis = get_analysis_for_user(session, current_user, analysis_id)
+    link = session.get(AnalysisTag, (analysis.id, tag.id))
+    if not link:
+        session.add(AnalysisTag(analysis_id=analysis.id, tag_id=tag.id))
+        session.commit()
+    return Message(message="Tag attached")
+
+
+@router.delete("/{id}/analyses/{analysis_id}", response_model=Message)
+def detach_tag_from_analysis(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    analysis_id: uuid.UUID,
+) -> Any:
+    """
+    Detach a tag from an analysis.
+    """
+    tag = get_tag_for_user(session, current_user, id)
+    analysis = get_analysis_for_user(session, current_user, analysis_id)
+    link = session.get(AnalysisTag, (analysis.id, tag.id))
+    if not link:
+        raise HTTPException(status_code=404, detail="Tag is not attached")
+    session.delete(link)
+    session.commit()
+    return Message(message="Tag detached")
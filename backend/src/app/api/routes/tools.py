@@ -0,0 +1,37 @@
+from typing import Any
+
+from fastapi import APIRouter, HTTPException
+
+from app.api.deps import CurrentUser
+from app.models import (
+    MonteCarloRequest,
+    MonteCarloResultPublic,
+    ReturnsRequest,
+    ReturnsResultPublic,
+)
+from app.services import valuation
+
+router = APIRouter(prefix="/tools", tags=["tools"])
+
+
+@router.post("/monte-carlo", response_model=MonteCarloResultPublic)
+def simulate_returns(
+    current_user: CurrentUser, params: MonteCarloRequest
+) -> Any:
+    """
+    Run a Monte Carlo simulation over exit value and dilution assumptions
+    and return the distribution of return multiples for a proposed check.
+    """
+    return valuation.run_monte_carlo(params)
+
+
+@router.post("/returns", response_model=ReturnsResultPublic)
+def compute_returns(current_user: CurrentUser, params: ReturnsRequest) -> Any:
+    """
+    Compute IRR and MOIC from a dated cashflow series. Negative amounts
+    are money invested, positive amounts money returned.
+    """
+    try:
+        return valuation.compute_returns(params.cashflows)
+    except ValueError as e:
+        raise HTTPException(status_code=422, detail=str(e))
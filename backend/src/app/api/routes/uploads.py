@@ -0,0 +1,203 @@
+import base64
+import re
+import uuid
+from typing import Any
+
+from fastapi import (
+    APIRouter,
+    BackgroundTasks,
+    File,
+    Form,
+    HTTPException,
+    Request,
+    UploadFile,
+)
+from sqlmodel import select
+
+from app.api import idempotency
+from app.api.deps import CurrentUser, SessionDep
+from app.core.config import settings
+from app.models import (
+    Company,
+    DownloadLinkPublic,
+    Highlight,
+    UploadAttach,
+    UploadedFile,
+    UploadedFilePublic,
+    UploadPage,
+    UploadPagePublic,
+    UploadStatus,
+)
+from app.services import storage
+from app.services.downloads import generate_download_token
+from app.services.uploads import (
+    content_type_mismatch,
+    process_upload,
+    sniff_content_type,
+)
+
+router = APIRouter(prefix="/uploads", tags=["uploads"])
+
+
+def get_upload_for_user(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> UploadedFile:
+    upload = session.get(UploadedFile, id)
+    if not upload:
+        raise HTTPException(status_code=404, detail="Upload not found")
+    if not current_user.is_superuser and (upload.owner_id != current_user.id):
+        raise HTTPException(status_code=400, detail="Not enough permissions")
+    return upload
+
+
+@router.post("/", response_model=UploadedFilePublic)
+async def create_upload(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    file: UploadFile = File(...),
+    company_id: uuid.UUID | None = Form(default=None),
+    background_tasks: BackgroundTasks,
+    request: Request,
+) -> Any:
+    """
+    Upload a document and queue it for processing. Retries carrying the same
+    ``Idempotency-Key`` header replay the first response instead of storing
+    the file twice.
+    """
+    key = idempotency.request_key(request)
+    if key:
+        cached = idempotency.cached_response(
+            session, current_user.id, "create_upload", key
+        )
+        if cached:
+            return cached
+    if company_id:
+        company = session.get(Company, company_id)
+        if not company:
+            raise HTTPException(status_code=404, detail="Company not found")
+        if not current_user.is_superuser and (company.owner_id != current_user.id):
+            raise HTTPException(status_code=400, detail="Not enough permissions")
+    content = await file.read()
+    if len(content) > settings.MAX_UPLOAD_SIZE:
+        raise HTTPException(status_code=413, detail="File is too large")
+    # Trust the bytes, not the extension: a binary renamed to .pdf is
+    # rejected before it is stored or parsed.
+    claimed = file.content_type or "application/octet-stream"
+    detected = sniff_content_type(content)
+    if content_type_mismatch(claimed, detected):
+        raise HTTPException(
+            status_code=415,
+            detail=f"File content ({detected}) does not match its declared type",
+        )
+    storage_path = storage.save_upload(file.filename or "upload", content)
+    upload = UploadedFile(
+        filename=file.filename or "upload",
+        content_type=claimed,
+        detected_content_type=detected,
+        size=len(content),
+        storage_path=storage_path,
+        company_id=company_id,
+        owner_id=current_user.id,
+    )
+    session.add(upload)
+    session.commit()
+    session.refresh(upload)
+    background_tasks.add_task(process_upload, upload.id)
+    if key:
+        idempotency.store_response(
+            session,
+            current_user.id,
+            "create_upload",
+            key,
+            UploadedFilePublic.model_validate(upload),
+        )
+    return upload
+
+
+@router.get("/{id}", response_model=UploadedFilePublic)
+def read_upload(session: SessionDep, current_user: CurrentUser, id: uuid.UUID) -> Any:
+    """
+    Get upload by ID.
+    """
+    return get_upload_for_user(session, current_user, id)
+
+
+@router.put("/{id}/company", response_model=UploadedFilePublic)
+def attach_upload_to_company(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    attach_in: UploadAttach,
+) -> Any:
+    """
+    Attach an upload to a company, or detach it by sending a null
+    ``company_id``.
+    """
+    upload = get_upload_for_user(session, current_user, id)
+    if attach_in.company_id is not None:
+        company = session.get(Company, attach_in.company_id)
+        if not company or company.deleted_at is not None:
+            raise HTTPException(status_code=404, detail="Company not found")
+        if not current_user.is_superuser and (company.owner_id != current_user.id):
+            raise HTTPException(status_code=400, detail="Not enough permissions")
+    upload.company_id = attach_in.company_id
+    session.add(upload)
+    session.commit()
+    session.refresh(upload)
+    return upload
+
+
+@router.post("/{id}/link", response_model=DownloadLinkPublic)
+def create_upload_link(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID, request: Request
+) -> Any:
+    """
+    Create a signed, expiring link to the raw file, safe to share in email.
+    """
+    upload = get_upload_for_user(session, current_user, id)
+    if upload.status == UploadStatus.INFECTED:
+        raise HTTPException(status_code=409, detail="File is quarantined")
+    token, expires_at = generate_download_token("file", upload.id)
+    return DownloadLinkPublic(
+        url=str(request.url_for("download_resource", token=token)),
+        expires_at=expires_at,
+    )
+
+
+@router.get("/{id}/pages/{n}", response_model=UploadPagePublic)
+def read_upload_page(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    n: int,
+    q: str | None = None,
+) -> Any:
+    """
+    Get one extracted page with its rendered image, if available, and the
+    offsets of the cited phrase ``q`` within the page text.
+    """
+    upload = get_upload_for_user(session, current_user, id)
+    statement = select(UploadPage).where(
+        UploadPage.upload_id == upload.id, UploadPage.number == n
+    )
+    page = session.exec(statement).first()
+    if not page:
+        raise HTTPException(status_code=404, detail="Page not found")
+    highlights = []
+    if q:
+        for match in re.finditer(re.escape(q), page.text, re.IGNORECASE):
+            highlights.append(Highlight(start=match.start(), end=match.end()))
+    image = None
+    if page.image_path:
+        image = base64.b64encode(storage.read_file(page.image_path)).decode()
+    elif upload.content_type.startswith("image/") and n == 1:
+        image = base64.b64encode(storage.read_file(upload.storage_path)).decode()
+    return UploadPagePublic(
+        upload_id=upload.id,
+        number=page.number,
+        text=page.text,
+        highlights=highlights,
+        image=image,
+    )
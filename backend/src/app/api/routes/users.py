@@ -24,6 +24,7 @@ from app.models import (
     UserUpdate,
     UserUpdateMe,
 )
+from app.services import privacy
 from app.utils import generate_new_account_email, send_email
 
 router = APIRouter(prefix="/users", tags=["users"])
@@ -125,6 +126,15 @@ def read_user_me(current_user: CurrentUser) -> Any:
     return current_user
 
 
+@router.get("/me/export")
+def export_user_me(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Export everything held for the current user as one machine-readable
+    JSON document, for data-portability requests.
+    """
+    return privacy.export_user(session, current_user)
+
+
 @router.delete("/me", response_model=Message)
 def delete_user_me(session: SessionDep, current_user: CurrentUser) -> Any:
     """
@@ -1,6 +1,7 @@
-from fastapi import APIRouter, Depends
+from fastapi import APIRouter, Depends, Response
 from pydantic.networks import EmailStr
 
+from app.api import csrf
 from app.api.deps import get_current_active_superuser
 from app.models import Message
 from app.utils import generate_test_email, send_email
@@ -26,6 +27,19 @@ def test_email(email_to: EmailStr) -> Message:
     return Message(message="Test email sent")
 
 
+@router.get("/csrf-token/", response_model=Message)
+def issue_csrf_token(response: Response) -> Message:
+    """
+    Issue a CSRF token for cookie-authenticated clients: set as a cookie
+    here and echoed back in ``X-CSRF-Token`` on state-changing requests.
+    """
+    token = csrf.new_token()
+    response.set_cookie(
+        csrf.CSRF_COOKIE, token, samesite="strict", secure=True
+    )
+    return Message(message=token)
+
+
 @router.get("/health-check/")
 async def health_check() -> bool:
     return True
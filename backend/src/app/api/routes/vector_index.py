@@ -0,0 +1,88 @@
+import uuid
+from typing import Any
+
+from fastapi import APIRouter, BackgroundTasks, Depends, HTTPException
+from sqlmodel import col, func, select
+
+from app.api.deps import CurrentUser, SessionDep, get_current_active_superuser
+from app.models import (
+    Company,
+    Organization,
+    VectorIndexJob,
+    VectorIndexJobCreate,
+    VectorIndexJobPublic,
+    VectorIndexJobsPublic,
+)
+from app.services.vector_index import run_index_job
+
+router = APIRouter(
+    prefix="/vector-index",
+    tags=["vector-index"],
+    dependencies=[Depends(get_current_active_superuser)],
+)
+
+
+@router.get("/jobs", response_model=VectorIndexJobsPublic)
+def read_index_jobs(session: SessionDep, skip: int = 0, limit: int = 100) -> Any:
+    """
+    Retrieve index maintenance jobs, most recent first.
+    """
+    count_statement = select(func.count()).select_from(VectorIndexJob)
+    count = session.exec(count_statement).one()
+    statement = (
+        select(VectorIndexJob)
+        .order_by(col(VectorIndexJob.created_at).desc())
+        .offset(skip)
+        .limit(limit)
+    )
+    jobs = session.exec(statement).all()
+    return VectorIndexJobsPublic(data=jobs, count=count)
+
+
+@router.post("/jobs", response_model=VectorIndexJobPublic)
+def create_index_job(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    job_in: VectorIndexJobCreate,
+    background_tasks: BackgroundTasks,
+) -> Any:
+    """
+    Start a rebuild, compact, or migrate job for a company or organization.
+
+    Until a persistent vector store exists, jobs validate the embeddings
+    configuration and finish as ``skipped`` rather than faking index work.
+    """
+    if (job_in.company_id is None) == (job_in.organization_id is None):
+        raise HTTPException(
+            status_code=422,
+            detail="Provide exactly one of company_id or organization_id",
+        )
+    if job_in.company_id and not session.get(Company, job_in.company_id):
+        raise HTTPException(status_code=404, detail="Company not found")
+    if job_in.organization_id and not session.get(
+        Organization, job_in.organization_id
+    ):
+        raise HTTPException(status_code=404, detail="Organization not found")
+    job = VectorIndexJob(
+        operation=job_in.operation,
+        company_id=job_in.company_id,
+        organization_id=job_in.organization_id,
+        created_by=current_user.id,
+    )
+    session.add(job)
+    session.commit()
+    session.refresh(job)
+    background_tasks.add_task(run_index_job, job.id)
+    return job
+
+
+@router.get("/jobs/{id}", response_model=VectorIndexJobPublic)
+def read_index_job(session: SessionDep, id: uuid.UUID) -> Any:
+    """
+    Get an index job and its progress.
+    """
+    job = session.get(VectorIndexJob, id)
+    if not job:
+        raise HTTPException(status_code=404, detail="Index job not found")
+    return job
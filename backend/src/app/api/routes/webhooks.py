@@ -0,0 +1,183 @@
+import secrets
+import uuid
+from typing import Any
+
+from fastapi import APIRouter, HTTPException
+from sqlmodel import col, func, select
+
+from app.api.deps import CurrentUser, SessionDep
+from app.models import (
+    WEBHOOK_EVENT_TYPES,
+    Message,
+    Webhook,
+    WebhookCreate,
+    WebhookDeliveriesPublic,
+    WebhookDelivery,
+    WebhookDeliveryPublic,
+    WebhookPublic,
+    WebhookPublicWithSecret,
+    WebhooksPublic,
+    WebhookUpdate,
+)
+from app.services import webhooks as webhook_service
+
+router = APIRouter(prefix="/webhooks", tags=["webhooks"])
+
+
+def _validate_events(events: list[str]) -> None:
+    unknown = [e for e in events if e not in WEBHOOK_EVENT_TYPES]
+    if unknown:
+        raise HTTPException(
+            status_code=422, detail=f"Unknown event types: {', '.join(unknown)}"
+        )
+
+
+def get_webhook_for_user(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Webhook:
+    webhook = session.get(Webhook, id)
+    if not webhook:
+        raise HTTPException(status_code=404, detail="Webhook not found")
+    if not current_user.is_superuser and (
+        webhook.organization_id != current_user.organization_id
+    ):
+        raise HTTPException(status_code=400, detail="Not enough permissions")
+    return webhook
+
+
+@router.get("/", response_model=WebhooksPublic)
+def read_webhooks(session: SessionDep, current_user: CurrentUser) -> Any:
+    """
+    Retrieve the organization's webhooks.
+    """
+    if not current_user.organization_id:
+        raise HTTPException(status_code=400, detail="User has no organization")
+    count_statement = (
+        select(func.count())
+        .select_from(Webhook)
+        .where(Webhook.organization_id == current_user.organization_id)
+    )
+    count = session.exec(count_statement).one()
+    statement = select(Webhook).where(
+        Webhook.organization_id == current_user.organization_id
+    )
+    webhooks = session.exec(statement).all()
+    return WebhooksPublic(data=webhooks, count=count)
+
+
+@router.post("/", response_model=WebhookPublicWithSecret)
+def create_webhook(
+    *, session: SessionDep, current_user: CurrentUser, webhook_in: WebhookCreate
+) -> Any:
+    """
+    Register a webhook. The signing secret is only returned here.
+    """
+    if not current_user.organization_id:
+        raise HTTPException(status_code=400, detail="User has no organization")
+    _validate_events(webhook_in.events)
+    webhook = Webhook.model_validate(
+        webhook_in,
+        update={
+            "organization_id": current_user.organization_id,
+            "secret": secrets.token_urlsafe(32),
+            "created_by": current_user.id,
+        },
+    )
+    session.add(webhook)
+    session.commit()
+    session.refresh(webhook)
+    return webhook
+
+
+@router.patch("/{id}", response_model=WebhookPublic)
+def update_webhook(
+    *,
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    webhook_in: WebhookUpdate,
+) -> Any:
+    """
+    Update a webhook.
+    """
+    webhook = get_webhook_for_user(session, current_user, id)
+    update_dict = webhook_in.model_dump(exclude_unset=True)
+    if "events" in update_dict:
+        _validate_events(update_dict["events"])
+    webhook.sqlmodel_update(update_dict)
+    session.add(webhook)
+    session.commit()
+    session.refresh(webhook)
+    return webhook
+
+
+@router.get("/{id}/deliveries", response_model=WebhookDeliveriesPublic)
+def read_webhook_deliveries(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    skip: int = 0,
+    limit: int = 100,
+) -> Any:
+    """
+    Get the delivery log for a webhook, most recent first.
+    """
+    webhook = get_webhook_for_user(session, current_user, id)
+    count_statement = (
+        select(func.count())
+        .select_from(WebhookDelivery)
+        .where(WebhookDelivery.webhook_id == webhook.id)
+    )
+    count = session.exec(count_statement).one()
+    statement = (
+        select(WebhookDelivery)
+        .where(WebhookDelivery.webhook_id == webhook.id)
+        .order_by(col(WebhookDelivery.created_at).desc())
+        .offset(skip)
+        .limit(limit)
+    )
+    deliveries = session.exec(statement).all()
+    return WebhookDeliveriesPublic(data=deliveries, count=count)
+
+
+@router.post(
+    "/{id}/deliveries/{delivery_id}/replay", response_model=WebhookDeliveryPublic
+)
+async def replay_webhook_delivery(
+    session: SessionDep,
+    current_user: CurrentUser,
+    id: uuid.UUID,
+    delivery_id: uuid.UUID,
+) -> Any:
+    """
+    Replay a delivery as a new outbox row, re-signed and marked as a replay.
+    """
+    webhook = get_webhook_for_user(session, current_user, id)
+    original = session.get(WebhookDelivery, delivery_id)
+    if not original or original.webhook_id != webhook.id:
+        raise HTTPException(status_code=404, detail="Delivery not found")
+    replay = WebhookDelivery(
+        webhook_id=webhook.id,
+        event_type=original.event_type,
+        payload=original.payload,
+        replay_of=original.id,
+    )
+    session.add(replay)
+    session.commit()
+    session.refresh(replay)
+    await webhook_service.attempt_delivery(session, replay)
+    session.refresh(replay)
+    return replay
+
+
+@router.delete("/{id}")
+def delete_webhook(
+    session: SessionDep, current_user: CurrentUser, id: uuid.UUID
+) -> Message:
+    """
+    Delete a webhook.
+    """
+    webhook = get_webhook_for_user(session, current_user, id)
+    session.delete(webhook)
+    session.commit()
+    return Message(message="Webhook deleted successfully")
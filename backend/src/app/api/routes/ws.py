@@ -0,0 +1,65 @@
+import asyncio
+
+import jwt
+from fastapi import APIRouter, WebSocket, WebSocketDisconnect, status
+from jwt.exceptions import InvalidTokenError
+from pydantic import ValidationError
+
+from app.api.deps import SessionDep
+from app.core import security
+from app.core.config import settings
+from app.models import TokenPayload, User
+from app.services.events import broker, workspace_topic
+
+router = APIRouter(tags=["ws"])
+
+WS_KEEPALIVE_SECONDS = 30
+
+
+def _authenticate_ws(session: SessionDep, token: str) -> User | None:
+    # Browsers cannot set headers on WebSocket connections, so the access
+    # token is passed as a query parameter instead.
+    try:
+        payload = jwt.decode(
+            token, settings.SECRET_KEY, algorithms=[security.ALGORITHM]
+        )
+        token_data = TokenPayload(**payload)
+    except (InvalidTokenError, ValidationError):
+        return None
+    user = session.get(User, token_data.sub)
+    if not user or not user.is_active:
+        return None
+    return user
+
+
+@router.websocket("/ws")
+async def workspace_updates(
+    websocket: WebSocket, session: SessionDep, token: str
+) -> None:
+    """
+    Push workspace events (analysis progress, upload processing, comments)
+    for all entities in the caller's organization.
+    """
+    user = _authenticate_ws(session, token)
+    if not user:
+        await websocket.close(code=status.WS_1008_POLICY_VIOLATION)
+        return
+    await websocket.accept()
+    topic = workspace_topic(
+        organization_id=user.organization_id, user_id=user.id
+    )
+    queue = broker.subscribe(topic)
+    try:
+        while True:
+            try:
+                event = await asyncio.wait_for(
+                    queue.get(), timeout=WS_KEEPALIVE_SECONDS
+                )
+            except TimeoutError:
+                await websocket.send_json({"type": "ping"})
+                continue
+            await websocket.send_json(event)
+    except WebSocketDisconnect:
+        pass
+    finally:
+        broker.unsubscribe(topic, queue)
@@ -68,6 +68,63 @@ class Settings(BaseSettings):
             path=self.POSTGRES_DB,
         )
 
+    # Local file storage root; swapped for S3/MinIO in deployments
+    UPLOADS_DIR: str = "uploads"
+
+    # Completed analyses older than this are flagged stale
+    ANALYSIS_STALE_AFTER_DAYS: int = 90
+
+    # How long signed download links stay valid
+    DOWNLOAD_TOKEN_EXPIRE_MINUTES: int = 15
+
+    # Public-data enrichment connectors; unset keys disable their connector
+    CRUNCHBASE_API_KEY: str | None = None
+    PROXYCURL_API_KEY: str | None = None
+    NEWSAPI_API_KEY: str | None = None
+    PATENTSVIEW_API_KEY: str | None = None
+    # Optional; raises GitHub rate limits for the activity connector
+    GITHUB_TOKEN: str | None = None
+    SIMILARWEB_API_KEY: str | None = None
+    TWITTER_BEARER_TOKEN: str | None = None
+    # Sanctions/PEP screening of founders and entities; unset disables it
+    OPENSANCTIONS_API_KEY: str | None = None
+
+    # Mask personal data (emails, phones, SSNs, named salaries) in text
+    # before it leaves for external LLM or embeddings providers
+    PII_REDACTION_ENABLED: bool = False
+
+    # ClamAV daemon for scanning uploads; unset host disables scanning
+    CLAMAV_HOST: str | None = None
+    CLAMAV_PORT: int = 3310
+
+    # Requests per minute each caller may make; 0 disables throttling
+    RATE_LIMIT_PER_MINUTE: int = 0
+
+    # Largest accepted request body in bytes; oversized requests get a 413
+    MAX_UPLOAD_SIZE: int = 50 * 1024 * 1024
+
+    # Stream audit events to a SIEM ("none" disables export)
+    AUDIT_EXPORT: Literal["none", "syslog", "http"] = "none"
+    AUDIT_SYSLOG_HOST: str = "localhost"
+    AUDIT_SYSLOG_PORT: int = 514
+    # Any JSON collector endpoint: Splunk HEC, Datadog, Elastic ingest
+    AUDIT_HTTP_URL: str = ""
+    AUDIT_HTTP_TOKEN: str | None = None
+
+    # Optional message bus for domain events ("none" disables publishing)
+    EVENT_BUS: Literal["none", "nats", "kafka"] = "none"
+    EVENT_BUS_URL: str = "nats://localhost:4222"
+    EVENT_BUS_SUBJECT_PREFIX: str = "liora"
+
+    # Embeddings; "local" expects an OpenAI-compatible server (e.g. TEI or
+    # Ollama serving bge) reachable at EMBEDDINGS_LOCAL_BASE_URL
+    EMBEDDINGS_PROVIDER: Literal["openai", "local"] = "openai"
+    EMBEDDINGS_BASE_URL: str = "https://api.openai.com/v1"
+    EMBEDDINGS_API_KEY: str | None = None
+    EMBEDDINGS_MODEL: str = "text-embedding-3-small"
+    EMBEDDINGS_LOCAL_BASE_URL: str = "http://localhost:8080/v1"
+    EMBEDDINGS_LOCAL_MODEL: str = "bge-small-en-v1.5"
+
     SMTP_TLS: bool = True
     SMTP_SSL: bool = False
     SMTP_PORT: int = 587
@@ -0,0 +1,42 @@
+"""Structured JSON logging.
+
+Every record is one JSON object carrying the request ID from
+``app.core.tracing`` when one is set, so a deal can be traced end to end:
+the API request that queued an analysis and every pipeline log line it
+produced share the same ID.
+"""
+
+import json
+import logging
+from datetime import datetime, timezone
+from typing import Any
+
+from app.core import tracing
+
+
+class JsonFormatter(logging.Formatter):
+    def format(self, record: logging.LogRecord) -> str:
+        entry: dict[str, Any] = {
+            "time": datetime.fromtimestamp(
+                record.created, tz=timezone.utc
+            ).isoformat(),
+            "level": record.levelname.lower(),
+            "logger": record.name,
+            "message": record.getMessage(),
+        }
+        request_id = tracing.get_request_id()
+        if request_id:
+            entry["request_id"] = request_id
+        if record.exc_info:
+            entry["exception"] = self.formatException(record.exc_info)
+        return json.dumps(entry)
+
+
+def configure_logging() -> None:
+    """Route the root logger through the JSON formatter. Idempotent, so
+    reloads under uvicorn don't stack handlers."""
+    handler = logging.StreamHandler()
+    handler.setFormatter(JsonFormatter())
+    root = logging.getLogger()
+    root.handlers = [handler]
+    root.setLevel(logging.INFO)
@@ -1,16 +1,53 @@
+import asyncio
+from collections.abc import AsyncGenerator, Awaitable, Callable
+from contextlib import asynccontextmanager
+
 import sentry_sdk
-from fastapi import FastAPI
+from fastapi import FastAPI, Request, Response
+from fastapi.encoders import jsonable_encoder
+from fastapi.exception_handlers import (
+    http_exception_handler,
+    request_validation_exception_handler,
+)
+from fastapi.exceptions import RequestValidationError
+from fastapi.responses import JSONResponse
 from fastapi.routing import APIRoute
-from starlette.middleware.cors import CORSMiddleware
-
+from starlette.exceptions import HTTPException as StarletteHTTPException
+from app.api import cors, csrf, errors, problems, ratelimit
 from app.api.main import api_router
+from app.core import tracing
 from app.core.config import settings
+from app.core.logging import configure_logging
+from app.services import (
+    digest,
+    error_reporting,
+    ip_allowlist,
+    retention,
+    scheduler,
+    webhooks,
+)
+
+
+configure_logging()
 
 
 def custom_generate_unique_id(route: APIRoute) -> str:
     return f"{route.tags[0]}-{route.name}"
 
 
+@asynccontextmanager
+async def lifespan(app: FastAPI) -> AsyncGenerator[None, None]:
+    retry_task = asyncio.create_task(webhooks.retry_loop())
+    digest_task = asyncio.create_task(digest.digest_loop())
+    scheduler_task = asyncio.create_task(scheduler.scheduler_loop())
+    retention_task = asyncio.create_task(retention.retention_loop())
+    yield
+    retry_task.cancel()
+    digest_task.cancel()
+    scheduler_task.cancel()
+    retention_task.cancel()
+
+
 if settings.SENTRY_DSN and settings.ENVIRONMENT != "local":
     sentry_sdk.init(dsn=str(settings.SENTRY_DSN), enable_tracing=True)
 
@@ -18,16 +55,173 @@ app = FastAPI(
     title=settings.PROJECT_NAME,
     openapi_url=f"{settings.API_V1_STR}/openapi.json",
     generate_unique_id_function=custom_generate_unique_id,
+    lifespan=lifespan,
 )
 
-# Set all CORS enabled origins
-if settings.all_cors_origins:
-    app.add_middleware(
-        CORSMiddleware,
-        allow_origins=settings.all_cors_origins,
-        allow_credentials=True,
-        allow_methods=["*"],
-        allow_headers=["*"],
+@app.exception_handler(StarletteHTTPException)
+async def problem_aware_http_exception_handler(
+    request: Request, exc: StarletteHTTPException
+) -> Response:
+    code = errors.code_for(exc.status_code, exc.detail)
+    if problems.wants_problem_json(request):
+        return problems.problem_response(
+            request, exc.status_code, exc.detail, headers=exc.headers, code=code
+        )
+    if exc.status_code == 304:
+        # Not-modified responses must stay body-less
+        return await http_exception_handler(request, exc)
+    return JSONResponse(
+        status_code=exc.status_code,
+        content={"detail": exc.detail, "code": code},
+        headers=exc.headers,
+    )
+
+
+@app.exception_handler(RequestValidationError)
+async def problem_aware_validation_handler(
+    request: Request, exc: RequestValidationError
+) -> Response:
+    if problems.wants_problem_json(request):
+        return problems.problem_response(
+            request,
+            422,
+            "Request validation failed",
+            errors=jsonable_encoder(exc.errors()),
+            code=errors.VALIDATION_ERROR_CODE,
+        )
+    return JSONResponse(
+        status_code=422,
+        content={
+            "detail": jsonable_encoder(exc.errors()),
+            "code": errors.VALIDATION_ERROR_CODE,
+        },
+    )
+
+
+@app.exception_handler(Exception)
+async def unhandled_exception_handler(request: Request, exc: Exception) -> Response:
+    # Crashed route handlers get reported with the request attached; the
+    # client sees a generic 500 rather than the exception text.
+    error_reporting.capture_exception(
+        exc, method=request.method, path=request.url.path
     )
+    detail = "Internal server error"
+    code = errors.code_for(500, detail)
+    if problems.wants_problem_json(request):
+        return problems.problem_response(request, 500, detail, code=code)
+    return JSONResponse(status_code=500, content={"detail": detail, "code": code})
+
+
+@app.middleware("http")
+async def csrf_middleware(
+    request: Request, call_next: Callable[[Request], Awaitable[Response]]
+) -> Response:
+    # Only cookie-authenticated state changes are at risk; bearer-token
+    # requests pass straight through (see app.api.csrf).
+    if not csrf.check_request(request):
+        return JSONResponse(
+            status_code=403,
+            content={
+                "detail": "CSRF token missing or invalid",
+                "code": "CSRF_INVALID",
+            },
+        )
+    return await call_next(request)
+
+
+@app.middleware("http")
+async def body_limit_middleware(
+    request: Request, call_next: Callable[[Request], Awaitable[Response]]
+) -> Response:
+    # Reject oversized bodies before they are read into memory. Chunked
+    # requests carry no Content-Length, so the upload routes re-check the
+    # actual size after reading.
+    content_length = request.headers.get("content-length")
+    if (
+        content_length
+        and content_length.isdigit()
+        and int(content_length) > settings.MAX_UPLOAD_SIZE
+    ):
+        return JSONResponse(
+            status_code=413,
+            content={"detail": "Request body is too large", "code": "FILE_TOO_LARGE"},
+        )
+    return await call_next(request)
+
+
+@app.middleware("http")
+async def rate_limit_middleware(
+    request: Request, call_next: Callable[[Request], Awaitable[Response]]
+) -> Response:
+    allowed, headers = ratelimit.check(request)
+    if not allowed:
+        return JSONResponse(
+            status_code=429,
+            content={"detail": "Rate limit exceeded", "code": "RATE_LIMITED"},
+            headers=headers,
+        )
+    response = await call_next(request)
+    response.headers.update(headers)
+    return response
+
+
+@app.middleware("http")
+async def ip_allowlist_middleware(
+    request: Request, call_next: Callable[[Request], Awaitable[Response]]
+) -> Response:
+    # Orgs with an allowlist only accept API calls from their configured
+    # CIDR ranges; everything else is rejected before the route runs.
+    if not await asyncio.to_thread(ip_allowlist.check_request, request):
+        return JSONResponse(
+            status_code=403,
+            content={
+                "detail": "Source IP is not allowlisted",
+                "code": "IP_NOT_ALLOWLISTED",
+            },
+        )
+    return await call_next(request)
+
+
+@app.middleware("http")
+async def request_id_middleware(
+    request: Request, call_next: Callable[[Request], Awaitable[Response]]
+) -> Response:
+    # Honor an inbound ID from a proxy or client, otherwise mint one, so every
+    # side effect of the request can be traced back to it.
+    request_id = (
+        request.headers.get(tracing.REQUEST_ID_HEADER) or tracing.new_request_id()
+    )
+    tracing.set_request_id(request_id)
+    response = await call_next(request)
+    response.headers[tracing.REQUEST_ID_HEADER] = request_id
+    return response
+
+
+@app.middleware("http")
+async def cors_middleware(
+    request: Request, call_next: Callable[[Request], Awaitable[Response]]
+) -> Response:
+    # Per-route-group CORS: public share endpoints and the authenticated
+    # API resolve to different policies (see app.api.cors). Declared last
+    # so Starlette makes it outermost: 403/413/429 responses
+    # short-circuited by the guard middlewares above still carry CORS
+    # headers, otherwise browsers would report an opaque network error.
+    origin = request.headers.get("origin")
+    if not origin:
+        return await call_next(request)
+    policy = cors.policy_for(request.url.path)
+    if policy is None:
+        return await call_next(request)
+    if (
+        request.method == "OPTIONS"
+        and "access-control-request-method" in request.headers
+    ):
+        return cors.preflight_response(request, policy)
+    response = await call_next(request)
+    headers = cors.response_headers(policy, origin)
+    if headers:
+        response.headers.update(headers)
+    return response
+
 
 app.include_router(api_router, prefix=settings.API_V1_STR)
@@ -1,7 +1,20 @@
 import uuid
+from datetime import date, datetime, timezone
+from enum import Enum
+from typing import Any
 
 from pydantic import EmailStr
-from sqlmodel import Field, Relationship, SQLModel
+from sqlmodel import JSON, Column, Field, Relationship, SQLModel
+
+
+def utcnow() -> datetime:
+    return datetime.now(timezone.utc)
+
+
+class UserRole(str, Enum):
+    ANALYST = "analyst"
+    PARTNER = "partner"
+    ADMIN = "admin"
 
 
 # Shared properties
@@ -10,6 +23,8 @@ class UserBase(SQLModel):
     is_active: bool = True
     is_superuser: bool = False
     full_name: str | None = Field(default=None, max_length=255)
+    role: UserRole = Field(default=UserRole.ANALYST)
+    notify_on_analysis: bool = True
 
 
 # Properties to receive via API on creation
@@ -32,6 +47,7 @@ class UserUpdate(UserBase):
 class UserUpdateMe(SQLModel):
     full_name: str | None = Field(default=None, max_length=255)
     email: EmailStr | None = Field(default=None, max_length=255)
+    notify_on_analysis: bool | None = None
 
 
 class UpdatePassword(SQLModel):
@@ -43,12 +59,16 @@ class UpdatePassword(SQLModel):
 class User(UserBase, table=True):
     id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
     hashed_password: str
+    organization_id: uuid.UUID | None = Field(
+        default=None, foreign_key="organization.id", nullable=True, ondelete="SET NULL"
+    )
     items: list["Item"] = Relationship(back_populates="owner", cascade_delete=True)
 
 
 # Properties to return via API, id is always required
 class UserPublic(UserBase):
     id: uuid.UUID
+    organization_id: uuid.UUID | None = None
 
 
 class UsersPublic(SQLModel):
@@ -56,6 +76,165 @@ class UsersPublic(SQLModel):
     count: int
 
 
+# Shared properties
+class OrganizationBase(SQLModel):
+    name: str = Field(min_length=1, max_length=255)
+
+
+# Database model, database table inferred from class name
+class Organization(OrganizationBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    settings: dict[str, Any] = Field(default_factory=dict, sa_column=Column(JSON))
+    created_at: datetime = Field(default_factory=utcnow)
+
+
+# Properties to return via API, id is always required
+class OrganizationPublic(OrganizationBase):
+    id: uuid.UUID
+    settings: dict[str, Any]
+
+
+# Per-organization embeddings selection, stored under
+# Organization.settings["embeddings"]
+class EmbeddingsConfig(SQLModel):
+    provider: str = Field(default="openai", max_length=64)
+    model: str | None = Field(default=None, max_length=255)
+
+
+# Per-organization weekly digest settings, stored under
+# Organization.settings["digest"]
+class DigestConfig(SQLModel):
+    enabled: bool = False
+    # 0 is Monday, matching datetime.weekday()
+    weekday: int = Field(default=0, ge=0, le=6)
+
+
+# Per-organization IP allowlist, stored under
+# Organization.settings["ip_allowlist"]; an empty list with enabled=True
+# would lock everyone out, so enforcement requires both
+class IpAllowlistConfig(SQLModel):
+    enabled: bool = False
+    # CIDR ranges (e.g. "10.0.0.0/8", "203.0.113.7/32") allowed to call
+    # the API as members of this organization
+    cidrs: list[str] = []
+
+
+# Per-organization data retention policy, stored under
+# Organization.settings["retention"]; None disables a rule
+class RetentionConfig(SQLModel):
+    # Purge any upload this many days after it was stored
+    upload_days: int | None = Field(default=None, ge=1)
+    # Purge uploads this many days after their deal was passed
+    passed_upload_days: int | None = Field(default=None, ge=1)
+
+
+# Per-organization Slack notification settings, stored under
+# Organization.settings["slack"]
+class SlackConfig(SQLModel):
+    webhook_url: str | None = Field(default=None, max_length=2000)
+    channel: str | None = Field(default=None, max_length=255)
+    enabled: bool = False
+
+
+# Per-organization Notion integration settings, stored under
+# Organization.settings["notion"]
+class NotionConfig(SQLModel):
+    api_key: str | None = Field(default=None, max_length=255)
+    database_id: str | None = Field(default=None, max_length=64)
+    enabled: bool = False
+
+
+# The Notion page created by a memo export
+class NotionPagePublic(SQLModel):
+    url: str
+
+
+# Per-organization currency settings, stored under
+# Organization.settings["currency"]
+class CurrencyConfig(SQLModel):
+    # ISO 4217 code extracted monetary values are normalized to
+    base_currency: str = Field(default="USD", min_length=3, max_length=3)
+
+
+# Per-organization scoring rubric, stored under
+# Organization.settings["rubric"]
+class RubricConfig(SQLModel):
+    # Metric name -> relative weight; the overall score is the weighted
+    # average of whichever weighted metrics the pipeline produced. Empty
+    # weights fall back to an equal-weight average.
+    weights: dict[str, float] = {}
+
+
+# Shared properties; ``definition`` is the declarative scoring DSL:
+# {"metrics": {<name>: {"base": 5.0, "signals": [{"path":
+# "public_data.github.stars_total", "op": "gte", "value": 1000,
+# "points": 1.0}]}}, "overall": {"weights": {<name>: 0.4}}}
+class ScoringModelBase(SQLModel):
+    name: str = Field(min_length=1, max_length=255)
+    definition: dict[str, Any] = Field(default_factory=dict, sa_column=Column(JSON))
+
+
+# Properties to receive on scoring model creation
+class ScoringModelCreate(ScoringModelBase):
+    # Whether the new version becomes the active one immediately
+    activate: bool = True
+
+
+# Database model, database table inferred from class name; versions are
+# immutable, a new upload becomes the next version
+class ScoringModel(ScoringModelBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    organization_id: uuid.UUID = Field(
+        foreign_key="organization.id", nullable=False, ondelete="CASCADE"
+    )
+    version: int = Field(default=1, ge=1)
+    is_active: bool = False
+    created_by: uuid.UUID = Field(
+        foreign_key="user.id", nullable=False, ondelete="CASCADE"
+    )
+    created_at: datetime = Field(default_factory=utcnow)
+
+
+# Properties to return via API, id is always required
+class ScoringModelPublic(ScoringModelBase):
+    id: uuid.UUID
+    organization_id: uuid.UUID
+    version: int
+    is_active: bool
+    created_at: datetime
+
+
+class ScoringModelsPublic(SQLModel):
+    data: list[ScoringModelPublic]
+    count: int
+
+
+# One org-defined metric scored alongside the built-in ones
+class CustomMetric(SQLModel):
+    name: str = Field(min_length=1, max_length=64)
+    # Scoring guidance shown to analysts and included in the memo
+    guidance: str | None = Field(default=None, max_length=2000)
+    # Signal keywords; matches across the gathered evidence raise the score
+    keywords: list[str] = []
+
+
+# Per-organization custom metric definitions, stored under
+# Organization.settings["custom_metrics"]
+class CustomMetricsConfig(SQLModel):
+    metrics: list[CustomMetric] = []
+
+
+# Per-organization report branding, stored under
+# Organization.settings["branding"]
+class BrandingConfig(SQLModel):
+    primary_color: str = Field(
+        default="#009688", pattern=r"^#[0-9a-fA-F]{6}$", max_length=7
+    )
+    footer_text: str | None = Field(default=None, max_length=500)
+    # Managed via the logo upload endpoint, not the config PUT
+    logo_path: str | None = Field(default=None, max_length=1024)
+
+
 # Shared properties
 class ItemBase(SQLModel):
     title: str = Field(min_length=1, max_length=255)
@@ -92,6 +271,1247 @@ class ItemsPublic(SQLModel):
     count: int
 
 
+# Curated industry list surfaced to clients; Company.industry stays free-form
+# so imported data is never rejected.
+INDUSTRIES = [
+    "software",
+    "fintech",
+    "healthcare",
+    "biotech",
+    "climate",
+    "consumer",
+    "deeptech",
+    "ecommerce",
+    "edtech",
+    "enterprise",
+    "hardware",
+    "marketplace",
+    "media",
+    "other",
+]
+
+
+class CompanyStage(str, Enum):
+    PRE_SEED = "pre_seed"
+    SEED = "seed"
+    SERIES_A = "series_a"
+    SERIES_B = "series_b"
+    SERIES_C_PLUS = "series_c_plus"
+    GROWTH = "growth"
+
+
+# Where a deal sits in the investment process, distinct from the company's
+# funding stage
+class PipelineStage(str, Enum):
+    SOURCED = "sourced"
+    SCREENING = "screening"
+    DILIGENCE = "diligence"
+    TERM_SHEET = "term_sheet"
+    INVESTED = "invested"
+    PASSED = "passed"
+
+
+# Shared properties
+class CompanyBase(SQLModel):
+    name: str = Field(min_length=1, max_length=255)
+    description: str | None = Field(default=None, max_length=2000)
+    industry: str | None = Field(default=None, max_length=255)
+    stage: CompanyStage | None = Field(default=None)
+    website: str | None = Field(default=None, max_length=255)
+    # Public GitHub organization, for developer-tool traction signals
+    github_org: str | None = Field(default=None, max_length=255)
+    # App Store numeric app ID, for consumer traction signals
+    app_store_id: str | None = Field(default=None, max_length=32)
+    # Twitter/X handle (without @), for social presence signals
+    twitter_handle: str | None = Field(default=None, max_length=64)
+    # Markets the company operates in (ISO country codes or "eu"), for
+    # jurisdiction-specific risk assessment
+    markets: list[str] = Field(default_factory=list, sa_column=Column(JSON))
+
+
+# Properties to receive on company creation
+class CompanyCreate(CompanyBase):
+    pass
+
+
+# Properties to receive on company update
+class CompanyUpdate(CompanyBase):
+    name: str | None = Field(default=None, min_length=1, max_length=255)  # type: ignore
+
+
+# Database model, database table inferred from class name
+class Company(CompanyBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    owner_id: uuid.UUID = Field(
+        foreign_key="user.id", nullable=False, ondelete="CASCADE"
+    )
+    created_at: datetime = Field(default_factory=utcnow)
+    pipeline_stage: PipelineStage = Field(default=PipelineStage.SOURCED)
+    # When the pipeline stage last changed; anchors retention windows
+    stage_changed_at: datetime | None = Field(default=None)
+    # Analyst responsible for driving the diligence on this deal
+    assignee_id: uuid.UUID | None = Field(
+        default=None, foreign_key="user.id", nullable=True, ondelete="SET NULL"
+    )
+    # Hidden from default lists but fully intact; for passed deals
+    archived: bool = Field(default=False)
+    # Soft-delete marker; rows stay for history until an admin purges them
+    deleted_at: datetime | None = Field(default=None)
+    analyses: list["Analysis"] = Relationship(
+        back_populates="company", cascade_delete=True
+    )
+    funding_rounds: list["FundingRound"] = Relationship(
+        back_populates="company", cascade_delete=True
+    )
+    founders: list["Founder"] = Relationship(
+        back_populates="company", cascade_delete=True
+    )
+
+
+# Properties to return via API, id is always required
+class CompanyPublic(CompanyBase):
+    id: uuid.UUID
+    owner_id: uuid.UUID
+    created_at: datetime
+    pipeline_stage: PipelineStage = PipelineStage.SOURCED
+    assignee_id: uuid.UUID | None = None
+    archived: bool = False
+
+
+class CompaniesPublic(SQLModel):
+    data: list[CompanyPublic]
+    count: int
+    # Set when paging by cursor; None on the last page
+    next_cursor: str | None = None
+
+
+# Move a company to another pipeline stage
+class CompanyStageUpdate(SQLModel):
+    pipeline_stage: PipelineStage
+
+
+# One kanban column: a stage and the companies currently in it
+class PipelineColumn(SQLModel):
+    stage: PipelineStage
+    companies: list[CompanyPublic]
+
+
+class PipelinePublic(SQLModel):
+    columns: list[PipelineColumn]
+
+
+# Reassign one company to another analyst
+class CompanyTransfer(SQLModel):
+    new_owner_id: uuid.UUID
+
+
+# Reassign every company covered by one analyst to another
+class CompanyBulkTransfer(SQLModel):
+    from_owner_id: uuid.UUID
+    to_owner_id: uuid.UUID
+
+
+# Shared properties
+class FundingRoundBase(SQLModel):
+    stage: CompanyStage
+    amount_usd: float | None = Field(default=None, ge=0)
+    announced_on: date
+    investors: list[str] = Field(default_factory=list, sa_column=Column(JSON))
+
+
+# Properties to receive on funding round creation
+class FundingRoundCreate(FundingRoundBase):
+    pass
+
+
+# Properties to receive on funding round update
+class FundingRoundUpdate(SQLModel):
+    stage: CompanyStage | None = None
+    amount_usd: float | None = Field(default=None, ge=0)
+    announced_on: date | None = None
+    investors: list[str] | None = None
+
+
+# Database model, database table inferred from class name
+class FundingRound(FundingRoundBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    company_id: uuid.UUID = Field(
+        foreign_key="company.id", nullable=False, ondelete="CASCADE"
+    )
+    created_at: datetime = Field(default_factory=utcnow)
+    company: Company | None = Relationship(back_populates="funding_rounds")
+
+
+# Properties to return via API, id is always required
+class FundingRoundPublic(FundingRoundBase):
+    id: uuid.UUID
+    company_id: uuid.UUID
+
+
+class FundingRoundsPublic(SQLModel):
+    data: list[FundingRoundPublic]
+    count: int
+
+
+# Shared properties
+class FounderBase(SQLModel):
+    name: str = Field(min_length=1, max_length=255)
+    title: str | None = Field(default=None, max_length=255)
+    linkedin_url: str | None = Field(default=None, max_length=512)
+
+
+# Properties to receive on founder creation
+class FounderCreate(FounderBase):
+    pass
+
+
+# Properties to receive on founder update
+class FounderUpdate(SQLModel):
+    name: str | None = Field(default=None, min_length=1, max_length=255)
+    title: str | None = Field(default=None, max_length=255)
+    linkedin_url: str | None = Field(default=None, max_length=512)
+
+
+# Database model, database table inferred from class name
+class Founder(FounderBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    company_id: uuid.UUID = Field(
+        foreign_key="company.id", nullable=False, ondelete="CASCADE"
+    )
+    # Work history and education filled in by the enrichment stage
+    profile: dict[str, Any] = Field(default_factory=dict, sa_column=Column(JSON))
+    created_at: datetime = Field(default_factory=utcnow)
+    company: Company | None = Relationship(back_populates="founders")
+
+
+# Properties to return via API, id is always required
+class FounderPublic(FounderBase):
+    id: uuid.UUID
+    company_id: uuid.UUID
+    profile: dict[str, Any]
+
+
+class FoundersPublic(SQLModel):
+    data: list[FounderPublic]
+    count: int
+
+
+class AnalysisStatus(str, Enum):
+    PENDING = "pending"
+    RUNNING = "running"
+    COMPLETED = "completed"
+    FAILED = "failed"
+
+
+# Body for assigning an analyst; null clears the assignment
+class AssigneeUpdate(SQLModel):
+    assignee_id: uuid.UUID | None = None
+
+
+# An analyst's open work: assigned deals, assigned analyses that have not
+# been approved, and reviews waiting on them
+class AnalystQueuePublic(SQLModel):
+    analyst_id: uuid.UUID
+    companies: list[CompanyPublic]
+    analyses: list["AnalysisPublic"]
+    reviews: list["AnalysisPublic"]
+
+
+# Body for an analyst override of pipeline scores; who, when, and why are
+# recorded on the analysis alongside the original values
+class ScoreOverrideRequest(SQLModel):
+    # Metric name -> adjusted 0-10 value; omitted metrics keep the
+    # pipeline's value
+    metrics: dict[str, float] = {}
+    recommendation: str | None = Field(default=None, max_length=64)
+    reason: str = Field(min_length=1, max_length=2000)
+
+
+# Human sign-off workflow gating an analysis before it reaches the IC
+class ReviewState(str, Enum):
+    DRAFT = "draft"
+    IN_REVIEW = "in_review"
+    APPROVED = "approved"
+    REJECTED = "rejected"
+
+
+# Body for submitting an analysis for review
+class ReviewSubmit(SQLModel):
+    # Optional specific reviewer; unassigned reviews can be picked up by
+    # anyone in the organization
+    reviewer_id: uuid.UUID | None = None
+
+
+# Body for recording a review decision
+class ReviewDecision(SQLModel):
+    approved: bool
+    note: str | None = Field(default=None, max_length=2000)
+
+
+class AnalysisStage(str, Enum):
+    DOCUMENT_PROCESSING = "document_processing"
+    ENRICHMENT = "enrichment"
+    COMPLIANCE_SCREENING = "compliance_screening"
+    MARKET_RESEARCH = "market_research"
+    NEWS_ANALYSIS = "news_analysis"
+    SCORING = "scoring"
+    MEMO_GENERATION = "memo_generation"
+
+
+# Shared properties
+class AnalysisBase(SQLModel):
+    status: AnalysisStatus = Field(default=AnalysisStatus.PENDING)
+    stage: AnalysisStage | None = Field(default=None)
+    progress: int = Field(default=0, ge=0, le=100)
+    score: float | None = Field(default=None)
+    error: str | None = Field(default=None, max_length=2000)
+    # Extracted from uploaded financial documents during processing
+    monthly_burn_usd: float | None = Field(default=None)
+    cash_on_hand_usd: float | None = Field(default=None)
+    runway_months: float | None = Field(default=None)
+    # Position in the company's evaluation history; completed analyses are
+    # immutable and re-runs become the next version
+    version: int = Field(default=1, ge=1)
+
+
+# Properties to receive on analysis creation
+class AnalysisCreate(SQLModel):
+    company_id: uuid.UUID
+    # Optional subset of the company's uploads the analysis should focus on
+    file_ids: list[uuid.UUID] = []
+    # Optional per-run rubric weights overriding the organization's rubric
+    weights: dict[str, float] = {}
+
+
+# Shared properties
+class PortfolioBase(SQLModel):
+    name: str = Field(min_length=1, max_length=255)
+    description: str | None = Field(default=None, max_length=2000)
+
+
+# Properties to receive on portfolio creation
+class PortfolioCreate(PortfolioBase):
+    pass
+
+
+# Properties to receive on portfolio update
+class PortfolioUpdate(SQLModel):
+    name: str | None = Field(default=None, min_length=1, max_length=255)
+    description: str | None = Field(default=None, max_length=2000)
+
+
+# Database model, database table inferred from class name
+class Portfolio(PortfolioBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    owner_id: uuid.UUID = Field(
+        foreign_key="user.id", nullable=False, ondelete="CASCADE"
+    )
+    created_at: datetime = Field(default_factory=utcnow)
+
+
+# Link table attaching companies to portfolios
+class PortfolioCompany(SQLModel, table=True):
+    portfolio_id: uuid.UUID = Field(
+        foreign_key="portfolio.id", primary_key=True, ondelete="CASCADE"
+    )
+    company_id: uuid.UUID = Field(
+        foreign_key="company.id", primary_key=True, ondelete="CASCADE"
+    )
+
+
+# Properties to return via API, id is always required
+class PortfolioPublic(PortfolioBase):
+    id: uuid.UUID
+    owner_id: uuid.UUID
+    created_at: datetime
+
+
+class PortfoliosPublic(SQLModel):
+    data: list[PortfolioPublic]
+    count: int
+
+
+# Aggregate analytics across a portfolio's companies
+class PortfolioAnalyticsPublic(SQLModel):
+    company_count: int
+    average_score: float | None
+    sector_exposure: dict[str, int]
+    stage_distribution: dict[str, int]
+    risk_concentration: dict[str, Any]
+
+
+# Shared properties; one cohort sample of a metric (e.g. gross margin for
+# seed-stage fintech), stored as raw values so percentiles can be computed
+class BenchmarkBase(SQLModel):
+    industry: str = Field(min_length=1, max_length=255)
+    stage: CompanyStage | None = Field(default=None)
+    metric: str = Field(min_length=1, max_length=64)
+    values: list[float] = Field(default_factory=list, sa_column=Column(JSON))
+
+
+# Database model, database table inferred from class name
+class Benchmark(BenchmarkBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    created_at: datetime = Field(default_factory=utcnow)
+
+
+# Properties to return via API, id is always required
+class BenchmarkPublic(BenchmarkBase):
+    id: uuid.UUID
+
+
+class BenchmarksPublic(SQLModel):
+    data: list[BenchmarkPublic]
+    count: int
+
+
+# Bulk import payload; rows replace existing cohorts with the same
+# industry/stage/metric
+class BenchmarksImport(SQLModel):
+    rows: list[BenchmarkBase] = Field(min_length=1)
+
+
+class ExitType(str, Enum):
+    ACQUISITION = "acquisition"
+    IPO = "ipo"
+
+
+# Shared properties; one comparable exit from the imported dataset
+class ExitCompBase(SQLModel):
+    company_name: str = Field(min_length=1, max_length=255)
+    industry: str = Field(min_length=1, max_length=255)
+    # Last private funding stage before the exit, when known
+    stage: CompanyStage | None = Field(default=None)
+    exit_type: ExitType
+    acquirer: str | None = Field(default=None, max_length=255)
+    exit_value_usd: float | None = Field(default=None, ge=0)
+    revenue_multiple: float | None = Field(default=None, ge=0)
+    exited_on: date
+
+
+# Database model, database table inferred from class name
+class ExitComp(ExitCompBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    created_at: datetime = Field(default_factory=utcnow)
+
+
+# Properties to return via API, id is always required
+class ExitCompPublic(ExitCompBase):
+    id: uuid.UUID
+
+
+class ExitCompsPublic(SQLModel):
+    data: list[ExitCompPublic]
+    count: int
+
+
+# Bulk import payload; rows are appended to the dataset
+class ExitCompsImport(SQLModel):
+    rows: list[ExitCompBase] = Field(min_length=1)
+
+
+# One dated cashflow; negative amounts are money invested, positive
+# amounts are money returned
+class Cashflow(SQLModel):
+    date: date
+    amount_usd: float
+
+
+class ReturnsRequest(SQLModel):
+    cashflows: list[Cashflow] = Field(min_length=2)
+
+
+class ReturnsResultPublic(SQLModel):
+    # Annualized IRR; None when the cashflows never change sign
+    irr: float | None
+    moic: float
+    total_invested_usd: float
+    total_returned_usd: float
+
+
+# Inputs for the Monte Carlo return simulation
+class MonteCarloRequest(SQLModel):
+    check_size_usd: float = Field(gt=0)
+    entry_valuation_usd: float = Field(gt=0)
+    exit_valuation_low_usd: float = Field(gt=0)
+    exit_valuation_high_usd: float = Field(gt=0)
+    # Fraction of ownership lost to later rounds, sampled uniformly
+    dilution_low: float = Field(default=0.2, ge=0, lt=1)
+    dilution_high: float = Field(default=0.5, ge=0, lt=1)
+    failure_probability: float = Field(default=0.5, ge=0, le=1)
+    iterations: int = Field(default=10_000, ge=100, le=100_000)
+
+
+class MonteCarloResultPublic(SQLModel):
+    iterations: int
+    mean_multiple: float
+    percentiles: dict[str, float]
+    probability_of_loss: float
+    probability_3x: float
+
+
+# Manually supplied unit-economics inputs, overriding extracted ones
+class UnitEconomicsInput(SQLModel):
+    cac_usd: float | None = Field(default=None, ge=0)
+    ltv_usd: float | None = Field(default=None, ge=0)
+    gross_margin_pct: float | None = Field(default=None, ge=0, le=100)
+    payback_months: float | None = Field(default=None, ge=0)
+
+
+# Database model, database table inferred from class name
+class Analysis(AnalysisBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    company_id: uuid.UUID = Field(
+        foreign_key="company.id", nullable=False, ondelete="CASCADE"
+    )
+    requested_by: uuid.UUID = Field(
+        foreign_key="user.id", nullable=False, ondelete="CASCADE"
+    )
+    results: dict[str, Any] = Field(default_factory=dict, sa_column=Column(JSON))
+    # ID of the request that started the analysis, for end-to-end tracing
+    trace_id: str | None = Field(default=None, max_length=64)
+    # The previous version this analysis supersedes, if any
+    supersedes_id: uuid.UUID | None = Field(
+        default=None, foreign_key="analysis.id", nullable=True, ondelete="SET NULL"
+    )
+    # Analyst responsible for working this analysis to a decision
+    assignee_id: uuid.UUID | None = Field(
+        default=None, foreign_key="user.id", nullable=True, ondelete="SET NULL"
+    )
+    review_state: ReviewState = Field(default=ReviewState.DRAFT)
+    reviewer_id: uuid.UUID | None = Field(
+        default=None, foreign_key="user.id", nullable=True, ondelete="SET NULL"
+    )
+    review_note: str | None = Field(default=None, max_length=2000)
+    reviewed_at: datetime | None = Field(default=None)
+    created_at: datetime = Field(default_factory=utcnow)
+    completed_at: datetime | None = Field(default=None)
+    company: Company | None = Relationship(back_populates="analyses")
+
+
+# Properties to return via API, id is always required
+class AnalysisPublic(AnalysisBase):
+    id: uuid.UUID
+    company_id: uuid.UUID
+    requested_by: uuid.UUID
+    results: dict[str, Any]
+    trace_id: str | None
+    supersedes_id: uuid.UUID | None
+    assignee_id: uuid.UUID | None
+    review_state: ReviewState
+    reviewer_id: uuid.UUID | None
+    review_note: str | None
+    reviewed_at: datetime | None
+    created_at: datetime
+    completed_at: datetime | None
+    # True when newer files exist or the analysis has aged past the
+    # configured threshold; computed at read time
+    is_stale: bool = False
+
+
+class AnalysesPublic(SQLModel):
+    data: list[AnalysisPublic]
+    count: int
+    # Set when paging by cursor; None on the last page
+    next_cursor: str | None = None
+
+
+# One column of a side-by-side comparison; ``scores`` holds None for
+# metrics other analyses produced but this one did not
+class AnalysisComparisonColumn(SQLModel):
+    analysis_id: uuid.UUID
+    company_id: uuid.UUID
+    company_name: str
+    completed_at: datetime | None
+    overall_score: float | None
+    recommendation: str
+    scores: dict[str, float | None]
+    insights: list[str]
+    risks: list[Any]
+
+
+class AnalysisComparisonPublic(SQLModel):
+    # Union of metric names across the compared analyses, in a stable order
+    metrics: list[str]
+    columns: list[AnalysisComparisonColumn]
+
+
+# One metric's movement between two analysis versions
+class MetricDelta(SQLModel):
+    metric: str
+    before: float | None
+    after: float | None
+    # None when the metric only exists on one side
+    delta: float | None
+
+
+class AnalysisDiffPublic(SQLModel):
+    analysis_id: uuid.UUID
+    against_id: uuid.UUID
+    overall_delta: float | None
+    metrics: list[MetricDelta]
+    # Risks present in the older analysis but gone from the newer one,
+    # and vice versa
+    risks_resolved: list[Any]
+    risks_introduced: list[Any]
+    recommendation_before: str
+    recommendation_after: str
+    recommendation_changed: bool
+
+
+# Inputs for the portfolio construction optimizer
+class AllocationRequest(SQLModel):
+    fund_size_usd: float = Field(gt=0)
+    min_check_usd: float = Field(gt=0)
+    max_check_usd: float = Field(gt=0)
+    # Target share of the fund per sector/stage as 0-1 fractions; sectors
+    # and stages without a target are unconstrained
+    sector_targets: dict[str, float] = {}
+    stage_targets: dict[str, float] = {}
+
+
+# One recommended check in the optimized allocation
+class AllocationLinePublic(SQLModel):
+    company_id: uuid.UUID
+    company_name: str
+    industry: str | None
+    stage: CompanyStage | None
+    score: float
+    check_usd: float
+
+
+class AllocationPublic(SQLModel):
+    fund_size_usd: float
+    allocated_usd: float
+    unallocated_usd: float
+    lines: list[AllocationLinePublic]
+    # Candidates that scored too low or did not fit the targets
+    skipped: list[str]
+
+
+# Inputs for the follow-on reserve recommendation
+class ReserveRequest(SQLModel):
+    fund_size_usd: float = Field(gt=0)
+    # Capital already deployed into the company
+    initial_check_usd: float = Field(gt=0)
+    # Expected ownership lost per future round, and how many rounds to
+    # reserve for
+    expected_dilution: float = Field(default=0.2, ge=0, lt=1)
+    rounds_ahead: int = Field(default=2, ge=1, le=5)
+    # Concentration limit: largest total position as a share of the fund
+    max_position_pct: float = Field(default=10.0, gt=0, le=100)
+
+
+class ReserveRecommendationPublic(SQLModel):
+    company_id: uuid.UUID
+    recommended_reserve_usd: float
+    # Capital needed to hold ownership flat through the reserved rounds
+    pro_rata_usd: float
+    performance_multiplier: float
+    priority: str
+    rationale: list[str]
+
+
+# One metric observation from a completed analysis run
+class MetricHistoryPoint(SQLModel):
+    analysis_id: uuid.UUID
+    version: int
+    completed_at: datetime | None
+    value: float
+
+
+class MetricHistoryPublic(SQLModel):
+    company_id: uuid.UUID
+    # Metric name -> observations, oldest run first
+    metrics: dict[str, list[MetricHistoryPoint]]
+
+
+# Aggregate deal quality for one sector; momentum compares the average
+# score of the most recent window against the window before it
+class SectorStatsPublic(SQLModel):
+    industry: str
+    company_count: int
+    analysis_count: int
+    average_score: float | None
+    momentum: float | None
+
+
+class SectorHeatmapPublic(SQLModel):
+    data: list[SectorStatsPublic]
+    count: int
+
+
+# Aggregates over one slice of completed analyses (a month or a stage)
+class TrendSlicePublic(SQLModel):
+    analysis_count: int
+    average_score: float | None
+    average_risk_count: float | None
+    # Recommendation label -> number of analyses that earned it
+    recommendations: dict[str, int]
+
+
+class TrendPointPublic(TrendSlicePublic):
+    # Calendar month the analyses completed in, as YYYY-MM
+    month: str
+
+
+class AnalysisTrendsPublic(SQLModel):
+    months: list[TrendPointPublic]
+    stages: dict[str, TrendSlicePublic]
+
+
+class ScheduleFrequency(str, Enum):
+    WEEKLY = "weekly"
+    MONTHLY = "monthly"
+    QUARTERLY = "quarterly"
+
+
+# Shared properties
+class AnalysisScheduleBase(SQLModel):
+    frequency: ScheduleFrequency = Field(default=ScheduleFrequency.QUARTERLY)
+    enabled: bool = True
+
+
+# Properties to receive on schedule creation
+class AnalysisScheduleCreate(AnalysisScheduleBase):
+    company_id: uuid.UUID
+
+
+# Properties to receive on schedule update
+class AnalysisScheduleUpdate(SQLModel):
+    frequency: ScheduleFrequency | None = None
+    enabled: bool | None = None
+
+
+# Database model, database table inferred from class name; at most one
+# schedule per company
+class AnalysisSchedule(AnalysisScheduleBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    company_id: uuid.UUID = Field(
+        foreign_key="company.id", nullable=False, unique=True, ondelete="CASCADE"
+    )
+    created_by: uuid.UUID = Field(
+        foreign_key="user.id", nullable=False, ondelete="CASCADE"
+    )
+    next_run_at: datetime
+    last_run_at: datetime | None = Field(default=None)
+    created_at: datetime = Field(default_factory=utcnow)
+
+
+# Properties to return via API, id is always required
+class AnalysisSchedulePublic(AnalysisScheduleBase):
+    id: uuid.UUID
+    company_id: uuid.UUID
+    next_run_at: datetime
+    last_run_at: datetime | None
+    created_at: datetime
+
+
+class AnalysisSchedulesPublic(SQLModel):
+    data: list[AnalysisSchedulePublic]
+    count: int
+
+
+# A pause marker for job consumption; a row with no organization pauses the
+# queue globally, otherwise just that organization
+class QueuePause(SQLModel, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    organization_id: uuid.UUID | None = Field(
+        default=None,
+        foreign_key="organization.id",
+        nullable=True,
+        unique=True,
+        ondelete="CASCADE",
+    )
+    paused_by: uuid.UUID = Field(
+        foreign_key="user.id", nullable=False, ondelete="CASCADE"
+    )
+    created_at: datetime = Field(default_factory=utcnow)
+
+
+class QueuePauseRequest(SQLModel):
+    organization_id: uuid.UUID | None = None
+
+
+class QueueStatePublic(SQLModel):
+    paused_globally: bool
+    paused_organizations: list[uuid.UUID]
+
+
+# Lightweight status snapshot for polling and event streams
+class AnalysisStatusPublic(SQLModel):
+    id: uuid.UUID
+    status: AnalysisStatus
+    stage: AnalysisStage | None
+    progress: int
+
+
+# Event types delivered to registered webhooks
+WEBHOOK_EVENT_TYPES = [
+    "analysis.completed",
+    "analysis.failed",
+    "upload.processed",
+    "alert.triggered",
+]
+
+
+ALERT_CHANNELS = ["email", "slack", "webhook"]
+
+
+# Shared properties
+class AlertRuleBase(SQLModel):
+    name: str = Field(min_length=1, max_length=255)
+    # Trigger when the overall score is at least this value
+    min_score: float | None = Field(default=None, ge=0, le=10)
+    # Trigger when any identified risk carries this category
+    risk_category: str | None = Field(default=None, max_length=255)
+    channels: list[str] = Field(default_factory=list, sa_column=Column(JSON))
+    is_active: bool = True
+
+
+# Properties to receive on alert rule creation
+class AlertRuleCreate(AlertRuleBase):
+    pass
+
+
+# Properties to receive on alert rule update
+class AlertRuleUpdate(SQLModel):
+    name: str | None = Field(default=None, min_length=1, max_length=255)
+    min_score: float | None = Field(default=None, ge=0, le=10)
+    risk_category: str | None = Field(default=None, max_length=255)
+    channels: list[str] | None = None
+    is_active: bool | None = None
+
+
+# Database model, database table inferred from class name
+class AlertRule(AlertRuleBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    user_id: uuid.UUID = Field(
+        foreign_key="user.id", nullable=False, ondelete="CASCADE"
+    )
+    created_at: datetime = Field(default_factory=utcnow)
+
+
+# Properties to return via API, id is always required
+class AlertRulePublic(AlertRuleBase):
+    id: uuid.UUID
+    user_id: uuid.UUID
+    created_at: datetime
+
+
+class AlertRulesPublic(SQLModel):
+    data: list[AlertRulePublic]
+    count: int
+
+
+# Shared properties
+class WebhookBase(SQLModel):
+    url: str = Field(min_length=1, max_length=2000)
+    events: list[str] = Field(default_factory=list, sa_column=Column(JSON))
+    is_active: bool = True
+
+
+# Properties to receive on webhook creation
+class WebhookCreate(WebhookBase):
+    pass
+
+
+# Properties to receive on webhook update
+class WebhookUpdate(SQLModel):
+    url: str | None = Field(default=None, min_length=1, max_length=2000)
+    events: list[str] | None = None
+    is_active: bool | None = None
+
+
+# Database model, database table inferred from class name
+class Webhook(WebhookBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    organization_id: uuid.UUID = Field(
+        foreign_key="organization.id", nullable=False, ondelete="CASCADE"
+    )
+    secret: str = Field(max_length=255)
+    created_by: uuid.UUID = Field(
+        foreign_key="user.id", nullable=False, ondelete="CASCADE"
+    )
+    created_at: datetime = Field(default_factory=utcnow)
+
+
+# Properties to return via API; the secret is only shown once, on creation
+class WebhookPublic(WebhookBase):
+    id: uuid.UUID
+    organization_id: uuid.UUID
+    created_at: datetime
+
+
+class WebhookPublicWithSecret(WebhookPublic):
+    secret: str
+
+
+class WebhooksPublic(SQLModel):
+    data: list[WebhookPublic]
+    count: int
+
+
+class WebhookDeliveryStatus(str, Enum):
+    PENDING = "pending"
+    DELIVERED = "delivered"
+    FAILED = "failed"
+
+
+# Outbox row for one event to one webhook; rows survive process restarts so
+# transient receiver failures never drop an event
+class WebhookDelivery(SQLModel, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    webhook_id: uuid.UUID = Field(
+        foreign_key="webhook.id", nullable=False, ondelete="CASCADE"
+    )
+    event_type: str = Field(max_length=255)
+    payload: dict[str, Any] = Field(default_factory=dict, sa_column=Column(JSON))
+    status: WebhookDeliveryStatus = Field(default=WebhookDeliveryStatus.PENDING)
+    attempts: int = Field(default=0)
+    next_attempt_at: datetime = Field(default_factory=utcnow)
+    last_error: str | None = Field(default=None, max_length=2000)
+    response_status: int | None = Field(default=None)
+    created_at: datetime = Field(default_factory=utcnow)
+    delivered_at: datetime | None = Field(default=None)
+    replay_of: uuid.UUID | None = Field(
+        default=None, foreign_key="webhookdelivery.id", nullable=True
+    )
+
+
+# Properties to return via API, id is always required
+class WebhookDeliveryPublic(SQLModel):
+    id: uuid.UUID
+    webhook_id: uuid.UUID
+    event_type: str
+    payload: dict[str, Any]
+    status: WebhookDeliveryStatus
+    attempts: int
+    next_attempt_at: datetime
+    last_error: str | None
+    response_status: int | None
+    created_at: datetime
+    delivered_at: datetime | None
+    replay_of: uuid.UUID | None
+
+
+class WebhookDeliveriesPublic(SQLModel):
+    data: list[WebhookDeliveryPublic]
+    count: int
+
+
+class UploadStatus(str, Enum):
+    UPLOADED = "uploaded"
+    PROCESSING = "processing"
+    PROCESSED = "processed"
+    FAILED = "failed"
+    # Virus scan flagged the file; it is quarantined, never parsed or served
+    INFECTED = "infected"
+
+
+class FileCategory(str, Enum):
+    PITCH_DECK = "pitch_deck"
+    FINANCIAL_MODEL = "financial_model"
+    CAP_TABLE = "cap_table"
+    LEGAL_DOC = "legal_doc"
+    OTHER = "other"
+
+
+# Database model, database table inferred from class name
+class UploadedFile(SQLModel, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    filename: str = Field(max_length=255)
+    content_type: str = Field(max_length=255)
+    # What the magic bytes actually say, as opposed to the claimed type
+    detected_content_type: str | None = Field(default=None, max_length=255)
+    size: int = Field(default=0)
+    storage_path: str = Field(max_length=1024)
+    status: UploadStatus = Field(default=UploadStatus.UPLOADED)
+    # Assigned automatically during processing
+    category: FileCategory = Field(default=FileCategory.OTHER)
+    error: str | None = Field(default=None, max_length=2000)
+    page_count: int | None = Field(default=None)
+    company_id: uuid.UUID | None = Field(
+        default=None, foreign_key="company.id", nullable=True, ondelete="CASCADE"
+    )
+    owner_id: uuid.UUID = Field(
+        foreign_key="user.id", nullable=False, ondelete="CASCADE"
+    )
+    created_at: datetime = Field(default_factory=utcnow)
+    pages: list["UploadPage"] = Relationship(
+        back_populates="upload", cascade_delete=True
+    )
+
+
+# One extracted page of an uploaded document, kept so citations can link
+# straight to their evidence
+class UploadPage(SQLModel, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    upload_id: uuid.UUID = Field(
+        foreign_key="uploadedfile.id", nullable=False, ondelete="CASCADE"
+    )
+    number: int = Field(ge=1)
+    text: str = Field(default="")
+    image_path: str | None = Field(default=None, max_length=1024)
+    upload: UploadedFile | None = Relationship(back_populates="pages")
+
+
+# Properties to return via API, id is always required
+class UploadedFilePublic(SQLModel):
+    id: uuid.UUID
+    filename: str
+    content_type: str
+    detected_content_type: str | None
+    size: int
+    status: UploadStatus
+    category: FileCategory
+    error: str | None
+    page_count: int | None
+    company_id: uuid.UUID | None
+    owner_id: uuid.UUID
+    created_at: datetime
+
+
+class UploadedFilesPublic(SQLModel):
+    data: list[UploadedFilePublic]
+    count: int
+
+
+# Attach an upload to a company (or detach it with null)
+class UploadAttach(SQLModel):
+    company_id: uuid.UUID | None = None
+
+
+class Highlight(SQLModel):
+    start: int
+    end: int
+
+
+# A rendered page with extracted text and offsets of the cited phrase
+class UploadPagePublic(SQLModel):
+    upload_id: uuid.UUID
+    number: int
+    text: str
+    highlights: list[Highlight] = []
+    # Base64-encoded image of the page when a rendering is available
+    image: str | None = None
+
+
+# Section keys report generation understands; templates order these and add
+# boilerplate around them
+REPORT_SECTION_KEYS = [
+    "profile",
+    "timeline",
+    "metrics",
+    "summary",
+    "scenarios",
+    "exits",
+    "insights",
+    "risks",
+]
+
+
+# Shared properties
+class ReportTemplateBase(SQLModel):
+    name: str = Field(min_length=1, max_length=255)
+    # Ordered sections: {"key": ..., "title": ..., "boilerplate": ...}
+    sections: list[dict[str, Any]] = Field(
+        default_factory=list, sa_column=Column(JSON)
+    )
+
+
+# Properties to receive on template creation
+class ReportTemplateCreate(ReportTemplateBase):
+    pass
+
+
+# Properties to receive on template update
+class ReportTemplateUpdate(SQLModel):
+    name: str | None = Field(default=None, min_length=1, max_length=255)
+    sections: list[dict[str, Any]] | None = None
+
+
+# Database model, database table inferred from class name
+class ReportTemplate(ReportTemplateBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    organization_id: uuid.UUID = Field(
+        foreign_key="organization.id", nullable=False, ondelete="CASCADE"
+    )
+    is_active: bool = False
+    created_by: uuid.UUID = Field(
+        foreign_key="user.id", nullable=False, ondelete="CASCADE"
+    )
+    created_at: datetime = Field(default_factory=utcnow)
+
+
+# Properties to return via API, id is always required
+class ReportTemplatePublic(ReportTemplateBase):
+    id: uuid.UUID
+    organization_id: uuid.UUID
+    is_active: bool
+    created_at: datetime
+
+
+class ReportTemplatesPublic(SQLModel):
+    data: list[ReportTemplatePublic]
+    count: int
+
+
+# Shared properties
+class TagBase(SQLModel):
+    name: str = Field(min_length=1, max_length=100)
+    color: str | None = Field(default=None, max_length=7)
+
+
+# Properties to receive on tag creation
+class TagCreate(TagBase):
+    pass
+
+
+# Database model, database table inferred from class name
+class Tag(TagBase, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    owner_id: uuid.UUID = Field(
+        foreign_key="user.id", nullable=False, ondelete="CASCADE"
+    )
+    created_at: datetime = Field(default_factory=utcnow)
+
+
+# Properties to return via API, id is always required
+class TagPublic(TagBase):
+    id: uuid.UUID
+    owner_id: uuid.UUID
+
+
+class TagsPublic(SQLModel):
+    data: list[TagPublic]
+    count: int
+
+
+# Link tables attaching tags to companies and analyses
+class CompanyTag(SQLModel, table=True):
+    company_id: uuid.UUID = Field(
+        foreign_key="company.id", primary_key=True, ondelete="CASCADE"
+    )
+    tag_id: uuid.UUID = Field(
+        foreign_key="tag.id", primary_key=True, ondelete="CASCADE"
+    )
+
+
+class AnalysisTag(SQLModel, table=True):
+    analysis_id: uuid.UUID = Field(
+        foreign_key="analysis.id", primary_key=True, ondelete="CASCADE"
+    )
+    tag_id: uuid.UUID = Field(
+        foreign_key="tag.id", primary_key=True, ondelete="CASCADE"
+    )
+
+
+# Caches the first response for a client-supplied Idempotency-Key so retried
+# writes don't create duplicates
+class IdempotencyKey(SQLModel, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    key: str = Field(max_length=255, index=True)
+    user_id: uuid.UUID = Field(
+        foreign_key="user.id", nullable=False, ondelete="CASCADE"
+    )
+    endpoint: str = Field(max_length=64)
+    response_body: dict[str, Any] = Field(
+        default_factory=dict, sa_column=Column(JSON)
+    )
+    created_at: datetime = Field(default_factory=utcnow)
+
+
+class VectorIndexOperation(str, Enum):
+    REBUILD = "rebuild"
+    COMPACT = "compact"
+    MIGRATE = "migrate"
+
+
+class VectorIndexJobStatus(str, Enum):
+    PENDING = "pending"
+    RUNNING = "running"
+    COMPLETED = "completed"
+    # No persistent vector store exists yet, so there was nothing to do
+    SKIPPED = "skipped"
+    FAILED = "failed"
+
+
+# Properties to receive on job creation; exactly one scope must be set
+class VectorIndexJobCreate(SQLModel):
+    operation: VectorIndexOperation
+    company_id: uuid.UUID | None = None
+    organization_id: uuid.UUID | None = None
+
+
+# Database model, database table inferred from class name
+class VectorIndexJob(SQLModel, table=True):
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    operation: VectorIndexOperation
+    company_id: uuid.UUID | None = Field(
+        default=None, foreign_key="company.id", nullable=True, ondelete="CASCADE"
+    )
+    organization_id: uuid.UUID | None = Field(
+        default=None, foreign_key="organization.id", nullable=True, ondelete="CASCADE"
+    )
+    status: VectorIndexJobStatus = Field(default=VectorIndexJobStatus.PENDING)
+    progress: int = Field(default=0, ge=0, le=100)
+    error: str | None = Field(default=None, max_length=2000)
+    created_by: uuid.UUID = Field(
+        foreign_key="user.id", nullable=False, ondelete="CASCADE"
+    )
+    created_at: datetime = Field(default_factory=utcnow)
+    completed_at: datetime | None = Field(default=None)
+
+
+# Properties to return via API, id is always required
+class VectorIndexJobPublic(SQLModel):
+    id: uuid.UUID
+    operation: VectorIndexOperation
+    company_id: uuid.UUID | None
+    organization_id: uuid.UUID | None
+    status: VectorIndexJobStatus
+    progress: int
+    error: str | None
+    created_at: datetime
+    completed_at: datetime | None
+
+
+class VectorIndexJobsPublic(SQLModel):
+    data: list[VectorIndexJobPublic]
+    count: int
+
+
+# Everything the SPA needs before first render, in one response
+class BootstrapPublic(SQLModel):
+    features: dict[str, bool]
+    enums: dict[str, list[str]]
+    limits: dict[str, int]
+    user: UserPublic
+    organization: OrganizationPublic | None = None
+
+
+# One search match, typed so the UI can route to the right detail view
+class SearchHit(SQLModel):
+    type: str
+    id: uuid.UUID
+    title: str
+    snippet: str | None = None
+
+
+# Search results grouped by entity
+class SearchResultsPublic(SQLModel):
+    companies: list[SearchHit]
+    analyses: list[SearchHit]
+    documents: list[SearchHit]
+
+
+# Signed, expiring link to a report or file download
+class DownloadLinkPublic(SQLModel):
+    url: str
+    expires_at: datetime
+
+
 # Generic message
 class Message(SQLModel):
     message: str
@@ -0,0 +1,82 @@
+import asyncio
+import logging
+import uuid
+
+from sqlmodel import Session, col, select
+
+from app.core.config import settings
+from app.models import AlertRule, Analysis, Company, User
+from app.services import slack, webhooks
+from app.utils import generate_alert_email, send_email
+
+logger = logging.getLogger(__name__)
+
+
+def rule_matches(rule: AlertRule, analysis: Analysis) -> bool:
+    if rule.min_score is not None:
+        if analysis.score is None or analysis.score < rule.min_score:
+            return False
+    if rule.risk_category is not None:
+        risks = analysis.results.get("risks", [])
+        categories = {r.get("category") for r in risks if isinstance(r, dict)}
+        if rule.risk_category not in categories:
+            return False
+    return rule.min_score is not None or rule.risk_category is not None
+
+
+def _rules_in_scope(
+    session: Session, organization_id: uuid.UUID | None, owner_id: uuid.UUID
+) -> list[AlertRule]:
+    statement = select(AlertRule).where(
+        AlertRule.is_active == True  # noqa: E712
+    )
+    if organization_id is not None:
+        statement = statement.join(
+            User, col(AlertRule.user_id) == col(User.id)
+        ).where(User.organization_id == organization_id)
+    else:
+        statement = statement.where(AlertRule.user_id == owner_id)
+    return list(session.exec(statement).all())
+
+
+async def evaluate_rules(
+    session: Session, organization_id: uuid.UUID | None, analysis: Analysis
+) -> None:
+    """Evaluate alert rules against a completed analysis and notify."""
+    company = session.get(Company, analysis.company_id)
+    if not company:
+        return
+    for rule in _rules_in_scope(session, organization_id, company.owner_id):
+        if not rule_matches(rule, analysis):
+            continue
+        if "email" in rule.channels and settings.emails_enabled:
+            user = session.get(User, rule.user_id)
+            if user:
+                email_data = generate_alert_email(
+                    email_to=user.email,
+                    rule_name=rule.name,
+                    company_name=company.name,
+                    analysis_id=str(analysis.id),
+                    score=analysis.score,
+                )
+                await asyncio.to_thread(
+                    send_email,
+                    email_to=user.email,
+                    subject=email_data.subject,
+                    html_content=email_data.html_content,
+                )
+        if "slack" in rule.channels:
+            await slack.post_analysis_summary(session, organization_id, analysis)
+        if "webhook" in rule.channels:
+            await webhooks.dispatch_event(
+                session,
+                organization_id,
+                "alert.triggered",
+                {
+                    "rule_id": str(rule.id),
+                    "rule_name": rule.name,
+                    "analysis_id": str(analysis.id),
+                    "company_id": str(company.id),
+                    "score": analysis.score,
+                },
+            )
@@ -0,0 +1,99 @@
+from sqlmodel import Session, col, select
+
+from app.models import (
+    AllocationLinePublic,
+    AllocationPublic,
+    AllocationRequest,
+    Analysis,
+    AnalysisStatus,
+    Company,
+)
+
+# Candidates scoring below this are left out of the allocation entirely.
+INVESTABLE_SCORE = 5.0
+
+
+def _latest_score(session: Session, company: Company) -> float | None:
+    latest = session.exec(
+        select(Analysis)
+        .where(
+            Analysis.company_id == company.id,
+            Analysis.status == AnalysisStatus.COMPLETED,
+        )
+        .order_by(col(Analysis.completed_at).desc())
+    ).first()
+    return latest.score if latest else None
+
+
+def _check_size(score: float, params: AllocationRequest) -> float:
+    """Scale the check with conviction: minimum check at an investable
+    score, maximum check at a perfect 10."""
+    span = 10.0 - INVESTABLE_SCORE
+    fraction = min(max(score - INVESTABLE_SCORE, 0.0) / span, 1.0)
+    return params.min_check_usd + fraction * (
+        params.max_check_usd - params.min_check_usd
+    )
+
+
+def optimize(
+    session: Session, candidates: list[Company], params: AllocationRequest
+) -> AllocationPublic:
+    """Greedily allocate the fund across the candidate set, best scores
+    first, respecting the target sector and stage mix."""
+    scored = []
+    skipped = []
+    for company in candidates:
+        score = _latest_score(session, company)
+        if score is None:
+            skipped.append(f"{company.name}: no completed analysis")
+        elif score < INVESTABLE_SCORE:
+            skipped.append(f"{company.name}: score {score:.1f} below threshold")
+        else:
+            scored.append((company, score))
+    scored.sort(key=lambda item: item[1], reverse=True)
+    sector_budgets = {
+        sector: target * params.fund_size_usd
+        for sector, target in params.sector_targets.items()
+    }
+    stage_budgets = {
+        stage: target * params.fund_size_usd
+        for stage, target in params.stage_targets.items()
+    }
+    remaining = params.fund_size_usd
+    lines = []
+    for company, score in scored:
+        check = _check_size(score, params)
+        sector = company.industry or "unknown"
+        stage = company.stage.value if company.stage else "unknown"
+        # Targets cap the check; a sector or stage without a target is
+        # unconstrained.
+        for budgets, key in ((sector_budgets, sector), (stage_budgets, stage)):
+            if key in budgets:
+                check = min(check, budgets[key])
+        check = min(check, remaining)
+        if check < params.min_check_usd:
+            skipped.append(f"{company.name}: no room within targets")
+            continue
+        lines.append(
+            AllocationLinePublic(
+                company_id=company.id,
+                company_name=company.name,
+                industry=company.industry,
+                stage=company.stage,
+                score=score,
+                check_usd=round(check, 2),
+            )
+        )
+        remaining -= check
+        if sector in sector_budgets:
+            sector_budgets[sector] -= check
+        if stage in stage_budgets:
+            stage_budgets[stage] -= check
+    allocated = params.fund_size_usd - remaining
+    return AllocationPublic(
+        fund_size_usd=params.fund_size_usd,
+        allocated_usd=round(allocated, 2),
+        unallocated_usd=round(remaining, 2),
+        lines=lines,
+        skipped=skipped,
+    )
@@ -0,0 +1,803 @@
+import asyncio
+import logging
+import uuid
+from collections.abc import Callable
+from datetime import timedelta, timezone
+from typing import Any
+
+from sqlmodel import Session, col, select
+
+from app.core import tracing
+from app.core.config import settings
+from app.core.db import engine
+from app.models import (
+    Analysis,
+    AnalysisStage,
+    AnalysisStatus,
+    Company,
+    CustomMetric,
+    CustomMetricsConfig,
+    FileCategory,
+    Founder,
+    FundingRound,
+    Organization,
+    RubricConfig,
+    UploadedFile,
+    User,
+    utcnow,
+)
+from app.utils import generate_analysis_complete_email, send_email
+from app.services import (
+    alerts,
+    benchmarks,
+    enrichment,
+    error_reporting,
+    exits,
+    financials,
+    fx,
+    geo_risk,
+    news,
+    queue,
+    scoring_model,
+    screening,
+    slack,
+    valuation,
+    webhooks,
+)
+from app.services.bus import publish_domain_event
+from app.services.events import broker, workspace_topic
+
+logger = logging.getLogger(__name__)
+
+StageHandler = Callable[[Session, Analysis], dict[str, Any]]
+
+
+def get_rubric_config(organization: Organization | None) -> RubricConfig:
+    if organization is None:
+        return RubricConfig()
+    return RubricConfig.model_validate(organization.settings.get("rubric", {}))
+
+
+def get_custom_metrics_config(
+    organization: Organization | None,
+) -> CustomMetricsConfig:
+    if organization is None:
+        return CustomMetricsConfig()
+    return CustomMetricsConfig.model_validate(
+        organization.settings.get("custom_metrics", {})
+    )
+
+
+def _organization_for(session: Session, analysis: Analysis) -> Organization | None:
+    company = session.get(Company, analysis.company_id)
+    owner = session.get(User, company.owner_id) if company else None
+    if owner and owner.organization_id:
+        return session.get(Organization, owner.organization_id)
+    return None
+
+
+def next_version(
+    session: Session, company_id: uuid.UUID
+) -> tuple[int, uuid.UUID | None]:
+    """Version number and predecessor for a new analysis of this company."""
+    latest = session.exec(
+        select(Analysis)
+        .where(Analysis.company_id == company_id)
+        .order_by(col(Analysis.version).desc(), col(Analysis.created_at).desc())
+    ).first()
+    if latest is None:
+        return 1, None
+    return latest.version + 1, latest.id
+
+
+def _run_document_processing(session: Session, analysis: Analysis) -> dict[str, Any]:
+    uploads = session.exec(
+        select(UploadedFile).where(UploadedFile.company_id == analysis.company_id)
+    ).all()
+    # Financial models are the most likely place to state burn and cash;
+    # fall back to scanning everything when none are categorized.
+    candidates = [
+        upload
+        for upload in uploads
+        if upload.category == FileCategory.FINANCIAL_MODEL
+    ] or uploads
+    figures: dict[str, Any] = {}
+    unit_inputs: dict[str, float] = {}
+    source_text = ""
+    for upload in candidates:
+        text = "\n".join(page.text for page in upload.pages)
+        if not figures:
+            figures = financials.extract_financials(text)
+            if figures:
+                source_text = text
+        if not unit_inputs:
+            unit_inputs = financials.extract_unit_economics(text)
+        if figures and unit_inputs:
+            break
+    if figures or unit_inputs:
+        # Normalize whatever currency the documents use to the org's base
+        # currency (USD unless configured otherwise).
+        base = fx.get_currency_config(
+            _organization_for(session, analysis)
+        ).base_currency
+        detected = fx.detect_currency(source_text)
+        if detected and detected != base:
+            figures = fx.normalize_figures(figures, detected, base)
+            unit_inputs = {
+                key: value
+                for key, value in fx.normalize_figures(
+                    unit_inputs, detected, base
+                ).items()
+                if key != "currency"
+            }
+    analysis.monthly_burn_usd = figures.get("monthly_burn_usd")
+    analysis.cash_on_hand_usd = figures.get("cash_on_hand_usd")
+    analysis.runway_months = figures.get("runway_months")
+    results: dict[str, Any] = {"documents": {"processed": len(uploads)}}
+    if figures:
+        results["financials"] = figures
+    if unit_inputs:
+        company = session.get(Company, analysis.company_id)
+        results["unit_economics"] = financials.compute_unit_economics(
+            unit_inputs, company.industry if company else None
+        )
+    return results
+
+
+def _run_enrichment(session: Session, analysis: Analysis) -> dict[str, Any]:
+    company = session.get(Company, analysis.company_id)
+    if not company:
+        return {"enrichment": {}}
+    results: dict[str, Any] = {"enrichment": {}}
+    if company.website:
+        try:
+            results["enrichment"] = enrichment.scrape_website(company.website)
+        except Exception:
+            # Scraping is best effort; a broken site must not fail the analysis.
+            logger.exception("Website enrichment failed for company %s", company.id)
+    stack = results["enrichment"].get("tech_stack")
+    if stack is not None:
+        results["technical"] = {
+            "stack": stack,
+            "observations": enrichment.tech_observations(stack),
+        }
+    founders = session.exec(
+        select(Founder).where(Founder.company_id == company.id)
+    ).all()
+    for founder in founders:
+        if founder.linkedin_url and not founder.profile:
+            profile = enrichment.fetch_linkedin_profile(founder.linkedin_url)
+            if profile:
+                founder.profile = profile
+                session.add(founder)
+    session.commit()
+    public_data = {}
+    for connector in enrichment.ENRICHMENT_CONNECTORS:
+        if not connector.is_configured():
+            continue
+        try:
+            data = connector.fetch(company)
+        except Exception:
+            logger.exception(
+                "Connector %s failed for company %s", connector.name, company.id
+            )
+            continue
+        if data:
+            public_data[connector.name] = data
+    if public_data:
+        results["public_data"] = public_data
+    return results
+
+
+def _run_compliance_screening(
+    session: Session, analysis: Analysis
+) -> dict[str, Any]:
+    company = session.get(Company, analysis.company_id)
+    if not company or not screening.is_configured():
+        return {}
+    founders = list(
+        session.exec(
+            select(Founder).where(Founder.company_id == company.id)
+        ).all()
+    )
+    # Deliberately no try/except: compliance must fail closed, so a broken
+    # screening provider fails the analysis instead of waving it through.
+    report = screening.screen(company, founders)
+    if not report:
+        return {}
+    results: dict[str, Any] = {"compliance": report}
+    red_flags = [
+        {
+            "category": "compliance",
+            "description": (
+                f"Sanctions/PEP hit: {hit['subject']} matched "
+                f"{hit['matched_name']} ({', '.join(hit['datasets'])})"
+            ),
+            "blocking": True,
+        }
+        for hit in report["hits"]
+        if hit["blocking"]
+    ]
+    if red_flags:
+        results["risks"] = red_flags
+    return results
+
+
+def _run_market_research(session: Session, analysis: Analysis) -> dict[str, Any]:
+    company = session.get(Company, analysis.company_id)
+    results: dict[str, Any] = {
+        "market": {"industry": company.industry if company else None}
+    }
+    # Traction summary drawn from the public-data connectors that ran in
+    # the enrichment stage.
+    public_data = analysis.results.get("public_data", {})
+    traction = {
+        key: public_data[key]
+        for key in ("github", "app_store", "web_traffic")
+        if public_data.get(key)
+    }
+    if traction:
+        results["traction"] = traction
+    if company:
+        landscape = exits.compile_comparables(session, company)
+        if landscape:
+            results["exit_landscape"] = landscape
+        geographic = geo_risk.assess(company)
+        if geographic:
+            results["geographic_risk"] = geographic
+    return results
+
+
+def _financial_health(rounds: list[FundingRound]) -> float:
+    """
+    Score funding history on a 0-10 scale: recent rounds and meaningful
+    totals raise it, a long gap since the last round lowers it.
+    """
+    score = 5.0
+    latest = max(round.announced_on for round in rounds)
+    age_days = (utcnow().date() - latest).days
+    if age_days <= 540:
+        score += 1.5
+    elif age_days > 1080:
+        score -= 1.5
+    total = sum(round.amount_usd or 0 for round in rounds)
+    if total >= 10_000_000:
+        score += 1.0
+    elif total >= 1_000_000:
+        score += 0.5
+    return max(0.0, min(10.0, score))
+
+
+def _team_strength(founders: list[Founder]) -> float:
+    """
+    Score the founding team on a 0-10 scale from enriched LinkedIn
+    profiles: prior founding experience and deep work histories raise it.
+    """
+    score = 5.0
+    seasoned = 0
+    prior_founder = False
+    for founder in founders:
+        experiences = founder.profile.get("experiences", [])
+        years = [
+            item.get("starts_at")
+            for item in experiences
+            if isinstance(item.get("starts_at"), int)
+        ]
+        if years and utcnow().year - min(years) >= 8:
+            seasoned += 1
+        if any(
+            "founder" in (item.get("title") or "").lower()
+            or "ceo" in (item.get("title") or "").lower()
+            for item in experiences
+        ):
+            prior_founder = True
+    if prior_founder:
+        score += 1.5
+    score += min(seasoned * 0.5, 1.5)
+    return max(0.0, min(10.0, score))
+
+
+def _run_news_analysis(session: Session, analysis: Analysis) -> dict[str, Any]:
+    company = session.get(Company, analysis.company_id)
+    if not company:
+        return {"news": {}}
+    press = news.gather_news(company.name)
+    if press is None:
+        return {"news": {}}
+    insights = [
+        f"{event['kind']}: {event['title']}" for event in press["notable_events"]
+    ]
+    return {"news": press, "insights": insights}
+
+
+def _defensibility(patents: dict[str, Any]) -> float:
+    """
+    Score the IP moat on a 0-10 scale: any granted patent helps, a
+    portfolio and recent grants help more.
+    """
+    score = 5.0
+    count = patents.get("patent_count") or 0
+    if count >= 1:
+        score += 1.0
+    if count >= 5:
+        score += 1.0
+    recent_cutoff = str(utcnow().year - 3)
+    if any(
+        (patent.get("granted_on") or "") >= recent_cutoff
+        for patent in patents.get("patents", [])
+    ):
+        score += 0.5
+    return max(0.0, min(10.0, score))
+
+
+def _product_viability(github: dict[str, Any]) -> float:
+    """
+    Score open-source traction on a 0-10 scale: stars, a healthy
+    contributor base, and a steady release cadence all raise it.
+    """
+    score = 5.0
+    stars = github.get("stars_total") or 0
+    if stars >= 10_000:
+        score += 2.0
+    elif stars >= 1_000:
+        score += 1.0
+    elif stars >= 100:
+        score += 0.5
+    if (github.get("contributors") or 0) >= 10:
+        score += 0.5
+    if (github.get("releases_last_year") or 0) >= 6:
+        score += 0.5
+    return max(0.0, min(10.0, score))
+
+
+def _market_potential(traffic: dict[str, Any]) -> float:
+    """
+    Score audience size and momentum on a 0-10 scale from estimated web
+    traffic: meaningful monthly visits and recent growth raise it.
+    """
+    score = 5.0
+    visits = traffic.get("monthly_visits") or []
+    latest = visits[-1].get("visits") if visits else None
+    if latest:
+        if latest >= 1_000_000:
+            score += 2.0
+        elif latest >= 100_000:
+            score += 1.0
+        elif latest >= 10_000:
+            score += 0.5
+    growth = traffic.get("growth")
+    if growth is not None:
+        if growth >= 0.25:
+            score += 1.0
+        elif growth < -0.1:
+            score -= 1.0
+    return max(0.0, min(10.0, score))
+
+
+def _brand_distribution(social: dict[str, Any]) -> float:
+    """
+    Score social reach on a 0-10 scale: audience size, a steady posting
+    cadence, and real engagement all raise it.
+    """
+    score = 5.0
+    followers = social.get("followers") or 0
+    if followers >= 100_000:
+        score += 2.0
+    elif followers >= 10_000:
+        score += 1.0
+    elif followers >= 1_000:
+        score += 0.5
+    if (social.get("posts_per_week") or 0) >= 3:
+        score += 0.5
+    if (social.get("avg_engagement") or 0) >= 50:
+        score += 0.5
+    return max(0.0, min(10.0, score))
+
+
+def _signal_corpus(analysis: Analysis) -> str:
+    """Flatten the evidence gathered so far into one lowercase haystack."""
+    parts = [
+        analysis.results.get("enrichment", {}).get("value_proposition") or "",
+        " ".join(analysis.results.get("insights", [])),
+        " ".join(
+            analysis.results.get("unit_economics", {}).get("commentary", [])
+        ),
+    ]
+    for event in analysis.results.get("news", {}).get("notable_events", []):
+        parts.append(event.get("title", ""))
+    return " ".join(parts).lower()
+
+
+def _custom_metric_score(metric: CustomMetric, corpus: str) -> float:
+    """
+    Score an org-defined metric on a 0-10 scale from keyword evidence: any
+    match lifts it off neutral, several distinct matches lift it further.
+    """
+    score = 5.0
+    hits = sum(
+        1 for keyword in metric.keywords if keyword.lower() in corpus
+    )
+    if hits >= 1:
+        score += 1.0
+    if hits >= 3:
+        score += 1.0
+    return max(0.0, min(10.0, score))
+
+
+def _overall_score(scores: dict[str, float], weights: dict[str, float]) -> float:
+    """
+    Roll sub-scores up into the overall score. With rubric weights the
+    overall is the weighted average of the weighted metrics that were
+    produced; otherwise every produced metric counts equally. Neutral 5.0
+    when there is nothing to aggregate.
+    """
+    if not scores:
+        return 5.0
+    weighted = [
+        (value, weights.get(metric, 0.0)) for metric, value in scores.items()
+    ]
+    total = sum(weight for _, weight in weighted)
+    if total <= 0:
+        return round(sum(scores.values()) / len(scores), 2)
+    return round(sum(value * weight for value, weight in weighted) / total, 2)
+
+
+def _score_range(score: float, evidence: int) -> dict[str, float]:
+    """
+    Confidence range around a metric value, wider when the evidence is
+    sparse: roughly ±2 on a single datapoint, narrowing toward ±0.5 as
+    datapoints accumulate.
+    """
+    margin = max(0.5, 2.0 - 0.15 * evidence)
+    return {
+        "low": max(0.0, round(score - margin, 2)),
+        "high": min(10.0, round(score + margin, 2)),
+    }
+
+
+def _run_scoring(session: Session, analysis: Analysis) -> dict[str, Any]:
+    scores: dict[str, Any] = {}
+    # Datapoints behind each metric, driving its confidence range.
+    evidence: dict[str, int] = {}
+    rounds = session.exec(
+        select(FundingRound).where(FundingRound.company_id == analysis.company_id)
+    ).all()
+    if rounds:
+        scores["financial_health"] = _financial_health(list(rounds))
+        evidence["financial_health"] = len(rounds)
+    founders = session.exec(
+        select(Founder).where(Founder.company_id == analysis.company_id)
+    ).all()
+    enriched = [founder for founder in founders if founder.profile]
+    if enriched:
+        scores["team_strength"] = _team_strength(enriched)
+        evidence["team_strength"] = len(enriched)
+    patents = analysis.results.get("public_data", {}).get("uspto_patents")
+    if patents is not None:
+        scores["defensibility"] = _defensibility(patents)
+        evidence["defensibility"] = len(patents.get("patents", []))
+    github = analysis.results.get("public_data", {}).get("github")
+    if github:
+        scores["product_viability"] = _product_viability(github)
+        evidence["product_viability"] = sum(
+            1 for value in github.values() if value
+        )
+    traffic = analysis.results.get("public_data", {}).get("web_traffic")
+    if traffic:
+        scores["market_potential"] = _market_potential(traffic)
+        evidence["market_potential"] = len(traffic.get("monthly_visits", []))
+    social = analysis.results.get("public_data", {}).get("social")
+    if social:
+        scores["brand_distribution"] = _brand_distribution(social)
+        evidence["brand_distribution"] = sum(
+            1 for value in social.values() if value
+        )
+    organization = _organization_for(session, analysis)
+    custom = get_custom_metrics_config(organization).metrics
+    if custom:
+        corpus = _signal_corpus(analysis)
+        for metric in custom:
+            key = metric.name.lower().replace(" ", "_")
+            scores.setdefault(key, _custom_metric_score(metric, corpus))
+    model = scoring_model.active_model(
+        session, organization.id if organization else None
+    )
+    model_weights: dict[str, float] = {}
+    if model:
+        dsl_scores, model_weights = scoring_model.evaluate(
+            model.definition, analysis.results
+        )
+        scores.update(dsl_scores)
+    # A per-run rubric (stored at creation) beats the active scoring
+    # model's weights, which beat the organization's rubric.
+    weights = (
+        analysis.results.get("rubric")
+        or model_weights
+        or get_rubric_config(organization).weights
+    )
+    analysis.score = _overall_score(scores, weights)
+    # Propagate per-metric uncertainty into the overall score by rolling
+    # up the lows and highs with the same weights as the point estimates.
+    ranges = {
+        metric: _score_range(value, evidence.get(metric, 1))
+        for metric, value in scores.items()
+    }
+    if ranges:
+        ranges["overall"] = {
+            "low": _overall_score(
+                {metric: r["low"] for metric, r in ranges.items()}, weights
+            ),
+            "high": _overall_score(
+                {metric: r["high"] for metric, r in ranges.items()}, weights
+            ),
+        }
+    scores = {"overall": analysis.score, **scores}
+    results: dict[str, Any] = {"scores": scores}
+    # The exact weights this run used, so later overrides recompute the
+    # overall under the same rules even if the model or rubric changes.
+    results["scoring_weights"] = weights
+    if ranges:
+        results["score_ranges"] = ranges
+    arr = analysis.results.get("financials", {}).get("arr_usd")
+    company = session.get(Company, analysis.company_id)
+    if company:
+        unit_metrics = analysis.results.get("unit_economics", {}).get("metrics", {})
+        metrics = {
+            metric: value
+            for metric, value in {
+                **analysis.results.get("financials", {}),
+                **unit_metrics,
+            }.items()
+            if metric in benchmarks.BENCHMARKED_METRICS
+        }
+        comparison = benchmarks.compare(session, company, metrics)
+        if comparison:
+            results["benchmarks"] = comparison
+    if arr and company:
+        results["valuation"] = valuation.estimate_valuation(company, arr)
+    scenarios = valuation.build_scenarios(analysis.results.get("financials", {}))
+    if scenarios:
+        results["scenarios"] = scenarios
+    return results
+
+
+def apply_score_overrides(
+    session: Session,
+    analysis: Analysis,
+    metric_overrides: dict[str, float],
+    recommendation_override: str | None,
+    actor_id: uuid.UUID,
+    reason: str,
+) -> None:
+    """Apply an analyst's adjustments on top of the pipeline's scores.
+
+    The pipeline's original scores are kept under ``scores_original`` the
+    first time anything is overridden, the overall score is recomputed
+    with the same weighting rules, and an audit entry records who changed
+    what and why.
+    """
+    results = dict(analysis.results)
+    scores = dict(results.get("scores", {}))
+    if "scores_original" not in results:
+        results["scores_original"] = dict(scores)
+    scores.update(metric_overrides)
+    sub_scores = {
+        metric: value
+        for metric, value in scores.items()
+        if metric != "overall" and isinstance(value, int | float)
+    }
+    # Recompute under the exact weights the pipeline used. Analyses that
+    # predate ``scoring_weights`` re-derive them with _run_scoring's
+    # precedence: per-run rubric, then model weights, then org rubric.
+    weights = results.get("scoring_weights")
+    if weights is None:
+        organization = _organization_for(session, analysis)
+        model = scoring_model.active_model(
+            session, organization.id if organization else None
+        )
+        model_weights: dict[str, float] = {}
+        if model:
+            _, model_weights = scoring_model.evaluate(model.definition, results)
+        weights = (
+            results.get("rubric")
+            or model_weights
+            or get_rubric_config(organization).weights
+        )
+    analysis.score = _overall_score(sub_scores, weights)
+    scores["overall"] = analysis.score
+    results["scores"] = scores
+    if recommendation_override is not None:
+        results["recommendation_override"] = recommendation_override
+    entries = list(results.get("overrides", []))
+    entries.append(
+        {
+            "by": str(actor_id),
+            "at": utcnow().isoformat(),
+            "reason": reason,
+            "metrics": metric_overrides,
+            "recommendation": recommendation_override,
+        }
+    )
+    results["overrides"] = entries
+    analysis.results = results
+
+
+def _run_memo_generation(session: Session, analysis: Analysis) -> dict[str, Any]:
+    company = session.get(Company, analysis.company_id)
+    name = company.name if company else "the company"
+    return {
+        "memo": {
+            "summary": f"Investment memo for {name}.",
+            # Ties the generated report back to the originating request.
+            "trace_id": analysis.trace_id,
+        }
+    }
+
+
+# Stages run in order; handlers return partial results merged into
+# ``Analysis.results``. New pipeline stages register here.
+PIPELINE_STAGES: list[tuple[AnalysisStage, StageHandler]] = [
+    (AnalysisStage.DOCUMENT_PROCESSING, _run_document_processing),
+    (AnalysisStage.ENRICHMENT, _run_enrichment),
+    (AnalysisStage.COMPLIANCE_SCREENING, _run_compliance_screening),
+    (AnalysisStage.MARKET_RESEARCH, _run_market_research),
+    (AnalysisStage.NEWS_ANALYSIS, _run_news_analysis),
+    (AnalysisStage.SCORING, _run_scoring),
+    (AnalysisStage.MEMO_GENERATION, _run_memo_generation),
+]
+
+
+def is_stale(session: Session, analysis: Analysis) -> bool:
+    """Whether a completed analysis no longer reflects the data room."""
+    if analysis.status != AnalysisStatus.COMPLETED or analysis.completed_at is None:
+        return False
+    completed_at = analysis.completed_at
+    if completed_at.tzinfo is None:
+        completed_at = completed_at.replace(tzinfo=timezone.utc)
+    if utcnow() - completed_at > timedelta(days=settings.ANALYSIS_STALE_AFTER_DAYS):
+        return True
+    newer_upload = session.exec(
+        select(UploadedFile).where(
+            UploadedFile.company_id == analysis.company_id,
+            UploadedFile.created_at > completed_at,  # type: ignore[arg-type]
+        )
+    ).first()
+    return newer_upload is not None
+
+
+def _workspace_topic_for(session: Session, analysis: Analysis) -> str:
+    company = session.get(Company, analysis.company_id)
+    owner = session.get(User, company.owner_id) if company else None
+    return workspace_topic(
+        organization_id=owner.organization_id if owner else None,
+        user_id=company.owner_id if company else analysis.requested_by,
+    )
+
+
+def _publish_progress(
+    analysis: Analysis,
+    partial: dict[str, Any] | None = None,
+    workspace: str | None = None,
+) -> None:
+    event: dict[str, Any] = {
+        "id": str(analysis.id),
+        "status": analysis.status,
+        "stage": analysis.stage,
+        "progress": analysis.progress,
+    }
+    if partial is not None:
+        event["partial_results"] = partial
+    broker.publish(f"analysis:{analysis.id}", event)
+    if workspace is not None:
+        broker.publish(workspace, {"type": "analysis.progress", "data": event})
+
+
+async def run_analysis(analysis_id: uuid.UUID) -> None:
+    """Execute the analysis pipeline, persisting and broadcasting progress."""
+    with Session(engine) as session:
+        analysis = session.get(Analysis, analysis_id)
+        if not analysis:
+            logger.warning("Analysis %s disappeared before it could run", analysis_id)
+            return
+        if analysis.trace_id:
+            # Pipeline log lines carry the ID of the request that queued
+            # the analysis, so one deal traces end to end.
+            tracing.set_request_id(analysis.trace_id)
+        if queue.is_paused(
+            session, queue.organization_for_analysis(session, analysis)
+        ):
+            # Leave the analysis pending; it is re-dispatched on resume.
+            logger.info("Queue paused, analysis %s stays pending", analysis_id)
+            return
+        workspace = _workspace_topic_for(session, analysis)
+        analysis.status = AnalysisStatus.RUNNING
+        session.add(analysis)
+        session.commit()
+        session.refresh(analysis)
+        _publish_progress(analysis, workspace=workspace)
+        try:
+            for index, (stage, handler) in enumerate(PIPELINE_STAGES):
+                analysis.stage = stage
+                analysis.progress = int(index * 100 / len(PIPELINE_STAGES))
+                session.add(analysis)
+                session.commit()
+                session.refresh(analysis)
+                _publish_progress(analysis, workspace=workspace)
+                partial = await asyncio.to_thread(handler, session, analysis)
+                analysis.results = {**analysis.results, **partial}
+                session.add(analysis)
+                session.commit()
+                session.refresh(analysis)
+                _publish_progress(analysis, partial, workspace=workspace)
+            analysis.status = AnalysisStatus.COMPLETED
+            analysis.stage = None
+            analysis.progress = 100
+        except Exception as e:
+            logger.exception("Analysis %s failed", analysis_id)
+            error_reporting.capture_exception(
+                e,
+                analysis_id=analysis_id,
+                company_id=analysis.company_id,
+                stage=analysis.stage,
+            )
+            analysis.status = AnalysisStatus.FAILED
+            analysis.error = str(e)
+        analysis.completed_at = utcnow()
+        session.add(analysis)
+        session.commit()
+        session.refresh(analysis)
+        _publish_progress(analysis, workspace=workspace)
+        event_type = (
+            "analysis.completed"
+            if analysis.status == AnalysisStatus.COMPLETED
+            else "analysis.failed"
+        )
+        organization_id = queue.organization_for_analysis(session, analysis)
+        if analysis.status == AnalysisStatus.COMPLETED:
+            await slack.post_analysis_summary(session, organization_id, analysis)
+            await alerts.evaluate_rules(session, organization_id, analysis)
+        await webhooks.dispatch_event(
+            session,
+            organization_id,
+            event_type,
+            {
+                "analysis_id": str(analysis.id),
+                "company_id": str(analysis.company_id),
+                "status": analysis.status,
+                "score": analysis.score,
+                "error": analysis.error,
+                "trace_id": analysis.trace_id,
+            },
+        )
+        await publish_domain_event(
+            event_type,
+            {
+                "analysis_id": str(analysis.id),
+                "company_id": str(analysis.company_id),
+                "score": analysis.score,
+            },
+        )
+        await asyncio.to_thread(_notify_requester, session, analysis)
+
+
+def _notify_requester(session: Session, analysis: Analysis) -> None:
+    """Email the requesting analyst about a finished analysis, if enabled."""
+    if not settings.emails_enabled:
+        return
+    requester = session.get(User, analysis.requested_by)
+    if not requester or not requester.notify_on_analysis:
+        return
+    company = session.get(Company, analysis.company_id)
+    email_data = generate_analysis_complete_email(
+        email_to=requester.email,
+        company_name=company.name if company else "your company",
+        analysis_id=str(analysis.id),
+        status=analysis.status.value,
+    )
+    headers = {"X-Liora-Trace-ID": analysis.trace_id} if analysis.trace_id else None
+    send_email(
+        email_to=requester.email,
+        subject=email_data.subject,
+        html_content=email_data.html_content,
+        headers=headers,
+    )
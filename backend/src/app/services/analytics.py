@@ -0,0 +1,124 @@
+from datetime import timedelta
+from typing import Any
+
+from sqlmodel import Session, col, select
+
+from app.models import (
+    Analysis,
+    AnalysisStatus,
+    AnalysisTrendsPublic,
+    Company,
+    SectorStatsPublic,
+    TrendPointPublic,
+    TrendSlicePublic,
+    utcnow,
+)
+from app.services.exports import analysis_recommendation
+
+# Momentum compares the average score of the last window against the
+# window before it, so a sector trending up reads positive.
+MOMENTUM_WINDOW_DAYS = 90
+
+
+def _average(values: list[float]) -> float | None:
+    return round(sum(values) / len(values), 2) if values else None
+
+
+def completed_rows(
+    session: Session, scope: Any
+) -> list[tuple[Analysis, Company]]:
+    """Completed analyses with their companies, restricted to ``scope``."""
+    statement = (
+        select(Analysis, Company)
+        .join(Company)
+        .where(
+            Analysis.status == AnalysisStatus.COMPLETED,
+            col(Company.deleted_at).is_(None),
+        )
+    )
+    if scope is not None:
+        statement = statement.where(scope)
+    return list(session.exec(statement).all())
+
+
+def sector_heatmap(session: Session, scope: Any) -> list[SectorStatsPublic]:
+    """Aggregate scores, deal counts, and momentum by industry."""
+    now = utcnow()
+    recent_cutoff = now - timedelta(days=MOMENTUM_WINDOW_DAYS)
+    prior_cutoff = now - timedelta(days=2 * MOMENTUM_WINDOW_DAYS)
+    sectors: dict[str, dict[str, Any]] = {}
+    for analysis, company in completed_rows(session, scope):
+        industry = company.industry or "unknown"
+        bucket = sectors.setdefault(
+            industry,
+            {"companies": set(), "scores": [], "recent": [], "prior": []},
+        )
+        bucket["companies"].add(company.id)
+        if analysis.score is None:
+            continue
+        bucket["scores"].append(analysis.score)
+        completed_at = analysis.completed_at
+        if completed_at is None:
+            continue
+        if completed_at.tzinfo is None:
+            completed_at = completed_at.replace(tzinfo=now.tzinfo)
+        if completed_at >= recent_cutoff:
+            bucket["recent"].append(analysis.score)
+        elif completed_at >= prior_cutoff:
+            bucket["prior"].append(analysis.score)
+    stats = []
+    for industry, bucket in sectors.items():
+        recent = _average(bucket["recent"])
+        prior = _average(bucket["prior"])
+        stats.append(
+            SectorStatsPublic(
+                industry=industry,
+                company_count=len(bucket["companies"]),
+                analysis_count=len(bucket["scores"]),
+                average_score=_average(bucket["scores"]),
+                momentum=(
+                    round(recent - prior, 2)
+                    if recent is not None and prior is not None
+                    else None
+                ),
+            )
+        )
+    stats.sort(key=lambda s: (s.average_score is None, -(s.average_score or 0)))
+    return stats
+
+
+def _slice(rows: list[tuple[Analysis, Company]]) -> TrendSlicePublic:
+    scores = [a.score for a, _ in rows if a.score is not None]
+    risk_counts = [len(a.results.get("risks", [])) for a, _ in rows]
+    recommendations: dict[str, int] = {}
+    for analysis, _ in rows:
+        label = analysis_recommendation(analysis)
+        recommendations[label] = recommendations.get(label, 0) + 1
+    return TrendSlicePublic(
+        analysis_count=len(rows),
+        average_score=_average(scores),
+        average_risk_count=_average([float(c) for c in risk_counts]),
+        recommendations=recommendations,
+    )
+
+
+def score_trends(session: Session, scope: Any) -> AnalysisTrendsPublic:
+    """How scores, risk counts, and recommendations trend by month of
+    completion and by company stage."""
+    rows = completed_rows(session, scope)
+    by_month: dict[str, list[tuple[Analysis, Company]]] = {}
+    by_stage: dict[str, list[tuple[Analysis, Company]]] = {}
+    for analysis, company in rows:
+        if analysis.completed_at is not None:
+            month = analysis.completed_at.strftime("%Y-%m")
+            by_month.setdefault(month, []).append((analysis, company))
+        stage = company.stage.value if company.stage else "unknown"
+        by_stage.setdefault(stage, []).append((analysis, company))
+    months = [
+        TrendPointPublic(month=month, **_slice(by_month[month]).model_dump())
+        for month in sorted(by_month)
+    ]
+    return AnalysisTrendsPublic(
+        months=months,
+        stages={stage: _slice(group) for stage, group in by_stage.items()},
+    )
@@ -0,0 +1,37 @@
+import socket
+import struct
+
+from app.core.config import settings
+
+# clamd INSTREAM chunk size; the daemon enforces its own StreamMaxLength.
+CHUNK_SIZE = 1 << 16
+
+SCAN_TIMEOUT_SECONDS = 30.0
+
+
+def scan_bytes(content: bytes) -> str | None:
+    """Stream content to the ClamAV daemon and return the signature name
+    when it is infected, None when clean or scanning is not configured.
+
+    Speaks the clamd INSTREAM protocol directly so no client library is
+    needed. Connection failures raise, so a dead daemon fails the upload
+    instead of waving files through unscanned.
+    """
+    if not settings.CLAMAV_HOST:
+        return None
+    with socket.create_connection(
+        (settings.CLAMAV_HOST, settings.CLAMAV_PORT),
+        timeout=SCAN_TIMEOUT_SECONDS,
+    ) as sock:
+        sock.sendall(b"zINSTREAM\0")
+        for start in range(0, len(content), CHUNK_SIZE):
+            chunk = content[start : start + CHUNK_SIZE]
+            sock.sendall(struct.pack("!I", len(chunk)) + chunk)
+        sock.sendall(struct.pack("!I", 0))
+        reply = sock.recv(4096).decode(errors="replace").strip("\x00\n ")
+    if reply.endswith("OK"):
+        return None
+    if reply.endswith("FOUND"):
+        # e.g. "stream: Eicar-Signature FOUND"
+        return reply.split(":", 1)[-1].rsplit("FOUND", 1)[0].strip()
+    raise RuntimeError(f"Unexpected clamd reply: {reply}")
@@ -0,0 +1,92 @@
+"""Audit event export to a SIEM.
+
+Security-relevant actions (logins, score overrides, review decisions)
+are streamed to the configured backend: RFC 5424 syslog over UDP, or an
+HTTP collector endpoint (Splunk HEC, Datadog, Elastic — anything that
+accepts JSON with a bearer token). Events carry a flat JSON envelope so
+downstream parsers need no custom pipeline.
+
+Delivery is best effort — a SIEM outage must never fail the request that
+produced the event.
+"""
+
+import json
+import logging
+import socket
+import uuid
+from typing import Any
+
+import httpx
+
+from app.core.config import settings
+from app.models import utcnow
+
+logger = logging.getLogger(__name__)
+
+# local0.info — the conventional slot for application audit trails
+SYSLOG_FACILITY = 16
+SYSLOG_SEVERITY = 6
+
+EXPORT_TIMEOUT_SECONDS = 5
+
+
+def _envelope(
+    event_type: str,
+    actor_id: uuid.UUID | None,
+    organization_id: uuid.UUID | None,
+    data: dict[str, Any],
+) -> dict[str, Any]:
+    return {
+        "id": str(uuid.uuid4()),
+        "type": event_type,
+        "occurred_at": utcnow().isoformat(),
+        "actor_id": str(actor_id) if actor_id else None,
+        "organization_id": str(organization_id) if organization_id else None,
+        "data": data,
+    }
+
+
+def _send_syslog(envelope: dict[str, Any]) -> None:
+    priority = SYSLOG_FACILITY * 8 + SYSLOG_SEVERITY
+    line = (
+        f"<{priority}>1 {envelope['occurred_at']} {socket.gethostname()} "
+        f"liora - - - {json.dumps(envelope)}"
+    )
+    with socket.socket(socket.AF_INET, socket.SOCK_DGRAM) as sock:
+        sock.sendto(
+            line.encode(),
+            (settings.AUDIT_SYSLOG_HOST, settings.AUDIT_SYSLOG_PORT),
+        )
+
+
+def _send_http(envelope: dict[str, Any]) -> None:
+    headers = {}
+    if settings.AUDIT_HTTP_TOKEN:
+        headers["Authorization"] = f"Bearer {settings.AUDIT_HTTP_TOKEN}"
+    response = httpx.post(
+        settings.AUDIT_HTTP_URL,
+        json=envelope,
+        headers=headers,
+        timeout=EXPORT_TIMEOUT_SECONDS,
+    )
+    response.raise_for_status()
+
+
+def emit(
+    event_type: str,
+    *,
+    actor_id: uuid.UUID | None = None,
+    organization_id: uuid.UUID | None = None,
+    data: dict[str, Any] | None = None,
+) -> None:
+    """Export one audit event; a no-op when no exporter is configured."""
+    if settings.AUDIT_EXPORT == "none":
+        return
+    envelope = _envelope(event_type, actor_id, organization_id, data or {})
+    try:
+        if settings.AUDIT_EXPORT == "syslog":
+            _send_syslog(envelope)
+        else:
+            _send_http(envelope)
+    except Exception:
+        logger.exception("Audit export of %s failed", event_type)
@@ -0,0 +1,66 @@
+"""Comparative scoring against industry benchmark cohorts.
+
+Benchmarks are imported by admins as raw cohort samples per
+industry/stage/metric. The scoring stage looks up each company metric's
+cohort and reports the percentile the company sits at.
+"""
+
+from typing import Any
+
+from sqlmodel import Session, func, select
+
+from app.models import Benchmark, Company
+
+# Metric keys the scoring stage tries to benchmark, mapped to where they
+# live in the analysis results.
+BENCHMARKED_METRICS = ("arr_usd", "gross_margin_pct", "ltv_cac_ratio")
+
+
+def percentile(values: list[float], value: float) -> float:
+    """Share of the cohort at or below ``value``, as a 0-100 percentile."""
+    if not values:
+        return 0.0
+    below = sum(1 for sample in values if sample <= value)
+    return round(below * 100 / len(values), 1)
+
+
+def _cohort(session: Session, company: Company, metric: str) -> Benchmark | None:
+    if not company.industry:
+        return None
+    statement = select(Benchmark).where(
+        func.lower(Benchmark.industry) == company.industry.lower(),
+        Benchmark.metric == metric,
+    )
+    rows = session.exec(statement).all()
+    # Prefer the stage-specific cohort, fall back to the industry-wide one.
+    for row in rows:
+        if company.stage is not None and row.stage == company.stage:
+            return row
+    for row in rows:
+        if row.stage is None:
+            return row
+    return rows[0] if rows else None
+
+
+def compare(
+    session: Session, company: Company, metrics: dict[str, float]
+) -> dict[str, Any]:
+    """
+    Compare a company's metrics against their benchmark cohorts. Metrics
+    without a matching cohort are left out.
+    """
+    comparison: dict[str, Any] = {}
+    for metric, value in metrics.items():
+        cohort = _cohort(session, company, metric)
+        if cohort is None or not cohort.values:
+            continue
+        comparison[metric] = {
+            "value": value,
+            "percentile": percentile(cohort.values, value),
+            "cohort": {
+                "industry": cohort.industry,
+                "stage": cohort.stage.value if cohort.stage else None,
+                "sample_size": len(cohort.values),
+            },
+        }
+    return comparison
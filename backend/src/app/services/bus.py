@@ -0,0 +1,83 @@
+import json
+import logging
+import uuid
+from typing import Any, Protocol
+
+from app.core.config import settings
+from app.models import utcnow
+
+logger = logging.getLogger(__name__)
+
+
+class MessageBus(Protocol):
+    async def publish(self, subject: str, payload: bytes) -> None: ...
+
+
+class NatsBus:
+    """Thin NATS publisher; nats-py is only required when EVENT_BUS=nats."""
+
+    def __init__(self, url: str) -> None:
+        self.url = url
+        self._client: Any = None
+
+    async def publish(self, subject: str, payload: bytes) -> None:
+        import nats
+
+        if self._client is None or self._client.is_closed:
+            self._client = await nats.connect(self.url)
+        await self._client.publish(subject, payload)
+
+
+class KafkaBus:
+    """Thin Kafka publisher; aiokafka is only required when EVENT_BUS=kafka."""
+
+    def __init__(self, url: str) -> None:
+        self.url = url
+        self._producer: Any = None
+
+    async def publish(self, subject: str, payload: bytes) -> None:
+        from aiokafka import AIOKafkaProducer
+
+        if self._producer is None:
+            self._producer = AIOKafkaProducer(bootstrap_servers=self.url)
+            await self._producer.start()
+        # Kafka topic names can't contain dots everywhere brokers are
+        # configured strictly, so subjects map onto dashed topics.
+        await self._producer.send_and_wait(subject.replace(".", "-"), payload)
+
+
+_bus: MessageBus | None = None
+
+
+def get_bus() -> MessageBus | None:
+    global _bus
+    if settings.EVENT_BUS == "none":
+        return None
+    if _bus is None:
+        if settings.EVENT_BUS == "nats":
+            _bus = NatsBus(settings.EVENT_BUS_URL)
+        else:
+            _bus = KafkaBus(settings.EVENT_BUS_URL)
+    return _bus
+
+
+async def publish_domain_event(event_type: str, data: dict[str, Any]) -> None:
+    """Emit a structured domain event; a no-op when no bus is configured.
+
+    Delivery is best effort — bus outages must never fail the request or
+    pipeline that produced the event.
+    """
+    bus = get_bus()
+    if bus is None:
+        return
+    envelope = {
+        "id": str(uuid.uuid4()),
+        "type": event_type,
+        "occurred_at": utcnow().isoformat(),
+        "data": data,
+    }
+    subject = f"{settings.EVENT_BUS_SUBJECT_PREFIX}.{event_type}"
+    try:
+        await bus.publish(subject, json.dumps(envelope).encode())
+    except Exception:
+        logger.exception("Domain event %s publish failed", event_type)
@@ -0,0 +1,136 @@
+import asyncio
+import logging
+from datetime import datetime, timedelta
+from typing import Any
+
+from sqlmodel import Session, col, select
+
+from app.core.config import settings
+from app.core.db import engine
+from app.models import (
+    Analysis,
+    AnalysisStatus,
+    Company,
+    DigestConfig,
+    Organization,
+    User,
+    utcnow,
+)
+from app.services import error_reporting
+from app.utils import generate_weekly_digest_email, send_email
+
+logger = logging.getLogger(__name__)
+
+DIGEST_POLL_SECONDS = 60 * 60
+DIGEST_WINDOW_DAYS = 7
+
+
+def get_digest_config(organization: Organization) -> DigestConfig:
+    return DigestConfig.model_validate(organization.settings.get("digest", {}))
+
+
+def compile_digest(
+    session: Session, organization: Organization
+) -> dict[str, Any] | None:
+    """Collect the org's past week of activity; None when there was none."""
+    since = utcnow() - timedelta(days=DIGEST_WINDOW_DAYS)
+    statement = (
+        select(Analysis, Company)
+        .join(Company, col(Analysis.company_id) == col(Company.id))
+        .join(User, col(Company.owner_id) == col(User.id))
+        .where(
+            User.organization_id == organization.id,
+            Analysis.status == AnalysisStatus.COMPLETED,
+            Analysis.completed_at >= since,  # type: ignore[arg-type]
+        )
+        .order_by(col(Analysis.completed_at).desc())
+    )
+    rows = session.exec(statement).all()
+    if not rows:
+        return None
+    analyses = []
+    score_changes = []
+    for analysis, company in rows:
+        analyses.append(
+            {"company": company.name, "score": analysis.score, "id": str(analysis.id)}
+        )
+        previous = session.exec(
+            select(Analysis)
+            .where(
+                Analysis.company_id == company.id,
+                Analysis.status == AnalysisStatus.COMPLETED,
+                Analysis.completed_at < analysis.completed_at,  # type: ignore[arg-type]
+            )
+            .order_by(col(Analysis.completed_at).desc())
+        ).first()
+        if (
+            previous
+            and previous.score is not None
+            and analysis.score is not None
+            and previous.score != analysis.score
+        ):
+            score_changes.append(
+                {
+                    "company": company.name,
+                    "from": previous.score,
+                    "to": analysis.score,
+                }
+            )
+    return {"analyses": analyses, "score_changes": score_changes}
+
+
+def send_digest(session: Session, organization: Organization) -> None:
+    digest = compile_digest(session, organization)
+    if digest is None:
+        return
+    members = session.exec(
+        select(User).where(
+            User.organization_id == organization.id,
+            User.is_active == True,  # noqa: E712
+        )
+    ).all()
+    for member in members:
+        email_data = generate_weekly_digest_email(
+            email_to=member.email,
+            organization_name=organization.name,
+            analyses=digest["analyses"],
+            score_changes=digest["score_changes"],
+        )
+        send_email(
+            email_to=member.email,
+            subject=email_data.subject,
+            html_content=email_data.html_content,
+        )
+
+
+async def send_due_digests() -> None:
+    """Send digests for orgs whose configured weekday has arrived."""
+    if not settings.emails_enabled:
+        return
+    now = utcnow()
+    with Session(engine) as session:
+        for organization in session.exec(select(Organization)).all():
+            config = get_digest_config(organization)
+            if not config.enabled or now.weekday() != config.weekday:
+                continue
+            last_sent = organization.settings.get("digest_last_sent")
+            if last_sent and (now - datetime.fromisoformat(last_sent)).days < 1:
+                continue
+            await asyncio.to_thread(send_digest, session, organization)
+            organization.settings = {
+                **organization.settings,
+                "digest_last_sent": now.isoformat(),
+            }
+            session.add(organization)
+            session.commit()
+
+
+async def digest_loop() -> None:
+    """Background loop sending weekly digests; started from the app lifespan."""
+    while True:
+        try:
+            await send_due_digests()
+        except Exception as e:
+            logger.exception("Digest loop iteration failed")
+            error_reporting.capture_exception(e, loop="digest")
+        await asyncio.sleep(DIGEST_POLL_SECONDS)
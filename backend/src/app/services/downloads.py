@@ -0,0 +1,48 @@
+from datetime import datetime, timedelta, timezone
+from typing import Any
+
+import jwt
+from jwt.exceptions import InvalidTokenError
+
+from app.core import security
+from app.core.config import settings
+
+# Restricts these tokens to the download endpoint; they are useless as
+# session tokens and vice versa.
+TOKEN_AUDIENCE = "download"
+
+
+def generate_download_token(
+    kind: str, resource_id: Any, format: str | None = None, lang: str | None = None
+) -> tuple[str, datetime]:
+    """Create a signed, expiring token granting access to one resource.
+
+    Returns the token and its expiry so callers can surface both.
+    """
+    now = datetime.now(timezone.utc)
+    expires = now + timedelta(minutes=settings.DOWNLOAD_TOKEN_EXPIRE_MINUTES)
+    claims: dict[str, Any] = {
+        "exp": expires,
+        "nbf": now,
+        "aud": TOKEN_AUDIENCE,
+        "sub": str(resource_id),
+        "kind": kind,
+    }
+    if format is not None:
+        claims["format"] = format
+    if lang is not None:
+        claims["lang"] = lang
+    token = jwt.encode(claims, settings.SECRET_KEY, algorithm=security.ALGORITHM)
+    return token, expires
+
+
+def verify_download_token(token: str) -> dict[str, Any] | None:
+    try:
+        return jwt.decode(
+            token,
+            settings.SECRET_KEY,
+            algorithms=[security.ALGORITHM],
+            audience=TOKEN_AUDIENCE,
+        )
+    except InvalidTokenError:
+        return None
@@ -0,0 +1,76 @@
+"""Duplicate company detection.
+
+Analysts often enter the same startup under slightly different names
+("Acme", "Acme Inc.", "acme labs"). Before creating a company we compare
+its normalized name and website domain against the caller's existing
+companies and surface likely duplicates so the record can be reused.
+"""
+
+import re
+from urllib.parse import urlparse
+
+from sqlmodel import Session, col, select
+
+from app.models import Company, User
+
+# Corporate suffixes ignored when comparing names.
+NAME_SUFFIXES = {
+    "inc",
+    "incorporated",
+    "llc",
+    "ltd",
+    "limited",
+    "gmbh",
+    "corp",
+    "corporation",
+    "co",
+    "company",
+    "labs",
+    "technologies",
+}
+
+
+def normalize_name(name: str) -> str:
+    """
+    Lowercase a company name, strip punctuation, and drop trailing
+    corporate suffixes so "Acme Labs, Inc." and "acme" compare equal.
+    """
+    tokens = re.findall(r"[a-z0-9]+", name.lower())
+    while tokens and tokens[-1] in NAME_SUFFIXES:
+        tokens.pop()
+    return " ".join(tokens)
+
+
+def website_domain(website: str | None) -> str | None:
+    """
+    Extract the bare domain from a website URL, ignoring scheme, a
+    leading "www.", and any path.
+    """
+    if not website:
+        return None
+    raw = website.strip()
+    if "://" not in raw:
+        raw = f"//{raw}"
+    domain = urlparse(raw).netloc.lower().removeprefix("www.")
+    return domain or None
+
+
+def find_duplicates(
+    session: Session, current_user: User, name: str, website: str | None
+) -> list[Company]:
+    """
+    Return the caller's active companies whose normalized name or website
+    domain matches the one being created.
+    """
+    target_name = normalize_name(name)
+    target_domain = website_domain(website)
+    statement = select(Company).where(col(Company.deleted_at).is_(None))
+    if not current_user.is_superuser:
+        statement = statement.where(Company.owner_id == current_user.id)
+    candidates = []
+    for company in session.exec(statement).all():
+        if target_name and normalize_name(company.name) == target_name:
+            candidates.append(company)
+        elif target_domain and website_domain(company.website) == target_domain:
+            candidates.append(company)
+    return candidates
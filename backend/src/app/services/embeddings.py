@@ -0,0 +1,86 @@
+from typing import Protocol
+
+import httpx
+
+from app.core.config import settings
+from app.models import EmbeddingsConfig, Organization
+from app.services import redaction
+
+EMBED_TIMEOUT_SECONDS = 30
+
+
+class EmbeddingsProvider(Protocol):
+    """Embedding backends are deliberately separate from chat LLMs; embedding
+    volume dominates cost for large data rooms, so orgs can route it to a
+    cheaper (often local) model independently."""
+
+    model: str
+
+    async def embed(self, texts: list[str]) -> list[list[float]]: ...
+
+
+class OpenAICompatibleEmbeddings:
+    """Any server speaking the OpenAI ``/embeddings`` protocol: OpenAI itself,
+    or a local bge deployment behind TEI/Ollama/vLLM."""
+
+    def __init__(
+        self, base_url: str, model: str, api_key: str | None = None
+    ) -> None:
+        self.base_url = base_url.rstrip("/")
+        self.model = model
+        self.api_key = api_key
+
+    async def embed(self, texts: list[str]) -> list[list[float]]:
+        headers = {}
+        if self.api_key:
+            headers["Authorization"] = f"Bearer {self.api_key}"
+        async with httpx.AsyncClient(timeout=EMBED_TIMEOUT_SECONDS) as client:
+            response = await client.post(
+                f"{self.base_url}/embeddings",
+                json={"model": self.model, "input": texts},
+                headers=headers,
+            )
+            response.raise_for_status()
+        data = sorted(response.json()["data"], key=lambda d: d["index"])
+        return [d["embedding"] for d in data]
+
+
+class RedactingEmbeddings:
+    """Masks personal data in outbound text before it reaches the wrapped
+    provider; the redaction stage for everything we embed externally."""
+
+    def __init__(self, inner: EmbeddingsProvider) -> None:
+        self.inner = inner
+        self.model = inner.model
+
+    async def embed(self, texts: list[str]) -> list[list[float]]:
+        return await self.inner.embed(
+            [redaction.redact_text(text) for text in texts]
+        )
+
+
+def get_embeddings_config(organization: Organization | None) -> EmbeddingsConfig:
+    if organization is None:
+        return EmbeddingsConfig(provider=settings.EMBEDDINGS_PROVIDER)
+    return EmbeddingsConfig.model_validate(
+        organization.settings.get("embeddings", {"provider": settings.EMBEDDINGS_PROVIDER})
+    )
+
+
+def get_provider(organization: Organization | None = None) -> EmbeddingsProvider:
+    """Resolve the embeddings provider for an org, falling back to defaults."""
+    config = get_embeddings_config(organization)
+    if config.provider == "local":
+        # Local deployments keep the text in-house; no redaction needed
+        return OpenAICompatibleEmbeddings(
+            base_url=settings.EMBEDDINGS_LOCAL_BASE_URL,
+            model=config.model or settings.EMBEDDINGS_LOCAL_MODEL,
+        )
+    provider = OpenAICompatibleEmbeddings(
+        base_url=settings.EMBEDDINGS_BASE_URL,
+        model=config.model or settings.EMBEDDINGS_MODEL,
+        api_key=settings.EMBEDDINGS_API_KEY,
+    )
+    if settings.PII_REDACTION_ENABLED:
+        return RedactingEmbeddings(provider)
+    return provider
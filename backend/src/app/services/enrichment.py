@@ -0,0 +1,630 @@
+"""Company enrichment from the company's own website.
+
+Fetches a handful of well-known pages (home, pricing, team, blog) and
+extracts lightweight signals — value proposition, pricing mentions, team
+page, blog cadence — that downstream pipeline stages merge into the
+analysis inputs. Scraping failures degrade to missing signals; they never
+fail the analysis.
+"""
+
+import logging
+import re
+from datetime import datetime, timedelta
+from typing import Any
+
+import httpx
+
+from app.core.config import settings
+from app.models import Company, utcnow
+from app.services.duplicates import website_domain
+
+logger = logging.getLogger(__name__)
+
+FETCH_TIMEOUT_SECONDS = 10
+
+PRICING_PATHS = ("/pricing", "/plans")
+TEAM_PATHS = ("/team", "/about")
+BLOG_PATHS = ("/blog", "/news")
+
+_META_DESCRIPTION_RE = re.compile(
+    r'<meta[^>]+name=["\']description["\'][^>]+content=["\']([^"\']+)["\']',
+    re.IGNORECASE,
+)
+_H1_RE = re.compile(r"<h1[^>]*>(.*?)</h1>", re.IGNORECASE | re.DOTALL)
+_TAG_RE = re.compile(r"<[^>]+>")
+_PRICE_RE = re.compile(r"[$€£]\s?\d[\d,.]*")
+_ISO_DATE_RE = re.compile(r"\b(20\d{2})-(\d{2})-(\d{2})\b")
+
+
+def _fetch(client: httpx.Client, url: str) -> str | None:
+    try:
+        response = client.get(url, follow_redirects=True)
+    except httpx.HTTPError as e:
+        logger.debug("Fetch of %s failed: %s", url, e)
+        return None
+    if response.status_code != 200:
+        return None
+    content_type = response.headers.get("content-type", "")
+    if "html" not in content_type:
+        return None
+    return response.text
+
+
+def _strip_tags(fragment: str) -> str:
+    return " ".join(_TAG_RE.sub(" ", fragment).split())
+
+
+def _value_proposition(home: str) -> str | None:
+    match = _META_DESCRIPTION_RE.search(home)
+    if match:
+        return _strip_tags(match.group(1))[:500]
+    match = _H1_RE.search(home)
+    if match:
+        return _strip_tags(match.group(1))[:500]
+    return None
+
+
+def _blog_cadence(page: str) -> dict[str, Any] | None:
+    dates = []
+    for match in _ISO_DATE_RE.finditer(page):
+        try:
+            dates.append(datetime(*(int(part) for part in match.groups())))
+        except ValueError:
+            continue
+    if not dates:
+        return None
+    dates = sorted(set(dates), reverse=True)[:10]
+    span_days = (dates[0] - dates[-1]).days
+    posts_per_month = (
+        round(len(dates) * 30 / span_days, 1) if span_days > 0 else float(len(dates))
+    )
+    return {
+        "last_post": dates[0].date().isoformat(),
+        "posts_per_month": posts_per_month,
+    }
+
+
+# Substrings that betray a technology's presence in the homepage HTML.
+TECH_FINGERPRINTS: dict[str, dict[str, tuple[str, ...]]] = {
+    "frameworks": {
+        "react": ("data-reactroot", "react-dom"),
+        "nextjs": ("/_next/", "__NEXT_DATA__"),
+        "vue": ("data-v-app", "vue.runtime"),
+        "angular": ("ng-version",),
+        "wordpress": ("wp-content", "wp-includes"),
+        "webflow": ("webflow.js", "w-nav"),
+    },
+    "analytics": {
+        "google_analytics": ("googletagmanager.com", "google-analytics.com"),
+        "segment": ("cdn.segment.com",),
+        "mixpanel": ("cdn.mxpnl.com", "mixpanel.init"),
+        "plausible": ("plausible.io/js",),
+        "hotjar": ("static.hotjar.com",),
+    },
+    "payments": {
+        "stripe": ("js.stripe.com",),
+        "paddle": ("cdn.paddle.com",),
+        "paypal": ("paypal.com/sdk",),
+        "chargebee": ("js.chargebee.com",),
+    },
+    "support": {
+        "intercom": ("widget.intercom.io",),
+        "zendesk": ("static.zdassets.com",),
+    },
+}
+
+
+def detect_tech_stack(home: str) -> dict[str, list[str]]:
+    """
+    Fingerprint the technologies visible in a homepage's HTML, grouped by
+    category. Only categories with at least one hit are returned.
+    """
+    stack: dict[str, list[str]] = {}
+    for category, fingerprints in TECH_FINGERPRINTS.items():
+        hits = [
+            tech
+            for tech, needles in fingerprints.items()
+            if any(needle.lower() in home.lower() for needle in needles)
+        ]
+        if hits:
+            stack[category] = hits
+    return stack
+
+
+def tech_observations(stack: dict[str, list[str]]) -> list[str]:
+    """
+    Turn a detected stack into the observations the technical assessment
+    flags.
+    """
+    observations = []
+    if not stack.get("analytics"):
+        observations.append(
+            "No analytics tooling detected on the site; the team may not be "
+            "measuring funnel performance."
+        )
+    if stack.get("payments"):
+        observations.append(
+            f"Self-serve payments integrated ({', '.join(stack['payments'])})."
+        )
+    if "wordpress" in stack.get("frameworks", []) or "webflow" in stack.get(
+        "frameworks", []
+    ):
+        observations.append(
+            "Site is built on a hosted site builder; likely a marketing "
+            "page rather than the product itself."
+        )
+    return observations
+
+
+def _first_page(
+    client: httpx.Client, base: str, paths: tuple[str, ...]
+) -> tuple[str, str] | None:
+    for path in paths:
+        url = f"{base}{path}"
+        page = _fetch(client, url)
+        if page:
+            return url, page
+    return None
+
+
+def scrape_website(website: str) -> dict[str, Any]:
+    """
+    Scrape a company website for enrichment signals. Returns only the
+    signals that could be extracted.
+    """
+    base = website if "://" in website else f"https://{website}"
+    base = base.rstrip("/")
+    signals: dict[str, Any] = {"source": base}
+    with httpx.Client(timeout=FETCH_TIMEOUT_SECONDS) as client:
+        home = _fetch(client, base)
+        if home:
+            value_proposition = _value_proposition(home)
+            if value_proposition:
+                signals["value_proposition"] = value_proposition
+            signals["tech_stack"] = detect_tech_stack(home)
+        pricing = _first_page(client, base, PRICING_PATHS)
+        if pricing:
+            url, page = pricing
+            signals["pricing_page"] = url
+            prices = _PRICE_RE.findall(_strip_tags(page))
+            if prices:
+                signals["price_points"] = prices[:10]
+        team = _first_page(client, base, TEAM_PATHS)
+        if team:
+            signals["team_page"] = team[0]
+        blog = _first_page(client, base, BLOG_PATHS)
+        if blog:
+            signals["blog_page"] = blog[0]
+            cadence = _blog_cadence(blog[1])
+            if cadence:
+                signals["blog_cadence"] = cadence
+    return signals
+
+
+class EnrichmentConnector:
+    """One external data provider attached as "public data" context.
+
+    Subclasses set ``name`` and implement ``is_configured`` and ``fetch``;
+    new providers register in ``ENRICHMENT_CONNECTORS``.
+    """
+
+    name: str
+
+    def is_configured(self) -> bool:
+        raise NotImplementedError
+
+    def fetch(self, company: Company) -> dict[str, Any]:
+        raise NotImplementedError
+
+
+class CrunchbaseConnector(EnrichmentConnector):
+    """Pulls funding history, investors, and headcount from Crunchbase."""
+
+    name = "crunchbase"
+
+    API_URL = "https://api.crunchbase.com/api/v4"
+    FIELD_IDS = "name,num_employees_enum,funding_total,investor_identifiers"
+
+    def is_configured(self) -> bool:
+        return bool(settings.CRUNCHBASE_API_KEY)
+
+    def _lookup_permalink(self, client: httpx.Client, name: str) -> str | None:
+        response = client.get(
+            f"{self.API_URL}/autocompletes",
+            params={"query": name, "collection_ids": "organizations", "limit": 1},
+        )
+        response.raise_for_status()
+        entities = response.json().get("entities", [])
+        if not entities:
+            return None
+        return entities[0].get("identifier", {}).get("permalink")
+
+    def fetch(self, company: Company) -> dict[str, Any]:
+        with httpx.Client(
+            timeout=FETCH_TIMEOUT_SECONDS,
+            headers={"X-cb-user-key": settings.CRUNCHBASE_API_KEY or ""},
+        ) as client:
+            permalink = self._lookup_permalink(client, company.name)
+            if not permalink:
+                return {}
+            response = client.get(
+                f"{self.API_URL}/entities/organizations/{permalink}",
+                params={
+                    "field_ids": self.FIELD_IDS,
+                    "card_ids": "raised_funding_rounds",
+                },
+            )
+            response.raise_for_status()
+            payload = response.json()
+        properties = payload.get("properties", {})
+        funding_total = properties.get("funding_total", {})
+        rounds = [
+            {
+                "name": item.get("identifier", {}).get("value"),
+                "announced_on": item.get("announced_on"),
+                "money_raised": item.get("money_raised", {}).get("value_usd"),
+            }
+            for item in payload.get("cards", {}).get("raised_funding_rounds", [])
+        ]
+        return {
+            "permalink": permalink,
+            "funding_total_usd": funding_total.get("value_usd"),
+            "funding_rounds": rounds,
+            "investors": [
+                investor.get("value")
+                for investor in properties.get("investor_identifiers", [])
+            ],
+            "headcount": properties.get("num_employees_enum"),
+        }
+
+
+class PatentsViewConnector(EnrichmentConnector):
+    """Pulls the company's US patent portfolio from PatentsView.
+
+    Trademarks need TSDR serial numbers we do not have, so the IP signal
+    is patents only for now; the defensibility score reads it from
+    ``public_data``.
+    """
+
+    name = "uspto_patents"
+
+    API_URL = "https://search.patentsview.org/api/v1/patent/"
+
+    def is_configured(self) -> bool:
+        return bool(settings.PATENTSVIEW_API_KEY)
+
+    def fetch(self, company: Company) -> dict[str, Any]:
+        with httpx.Client(
+            timeout=FETCH_TIMEOUT_SECONDS,
+            headers={"X-Api-Key": settings.PATENTSVIEW_API_KEY or ""},
+        ) as client:
+            response = client.post(
+                self.API_URL,
+                json={
+                    "q": {
+                        "assignees.assignee_organization": company.name,
+                    },
+                    "f": ["patent_id", "patent_title", "patent_date"],
+                    "o": {"size": 25},
+                    "s": [{"patent_date": "desc"}],
+                },
+            )
+            response.raise_for_status()
+            payload = response.json()
+        patents = payload.get("patents") or []
+        return {
+            "patent_count": payload.get("total_hits", len(patents)),
+            "patents": [
+                {
+                    "id": patent.get("patent_id"),
+                    "title": patent.get("patent_title"),
+                    "granted_on": patent.get("patent_date"),
+                }
+                for patent in patents
+            ],
+        }
+
+
+class GitHubConnector(EnrichmentConnector):
+    """Collects open-source traction signals for a company's GitHub org.
+
+    Only runs for companies with ``github_org`` set. A token is optional;
+    it just raises the rate limit.
+    """
+
+    name = "github"
+
+    API_URL = "https://api.github.com"
+
+    def is_configured(self) -> bool:
+        # The public API works without a token.
+        return True
+
+    def _headers(self) -> dict[str, str]:
+        headers = {"Accept": "application/vnd.github+json"}
+        if settings.GITHUB_TOKEN:
+            headers["Authorization"] = f"Bearer {settings.GITHUB_TOKEN}"
+        return headers
+
+    def fetch(self, company: Company) -> dict[str, Any]:
+        if not company.github_org:
+            return {}
+        with httpx.Client(
+            timeout=FETCH_TIMEOUT_SECONDS, headers=self._headers()
+        ) as client:
+            response = client.get(
+                f"{self.API_URL}/orgs/{company.github_org}/repos",
+                params={"sort": "pushed", "per_page": 20},
+            )
+            response.raise_for_status()
+            repos = response.json()
+            if not repos:
+                return {"org": company.github_org, "stars_total": 0}
+            top = max(repos, key=lambda repo: repo.get("stargazers_count", 0))
+            contributors = client.get(
+                f"{self.API_URL}/repos/{top['full_name']}/contributors",
+                params={"per_page": 100},
+            )
+            releases = client.get(
+                f"{self.API_URL}/repos/{top['full_name']}/releases",
+                params={"per_page": 20},
+            )
+        year_cutoff = str(utcnow().year - 1)
+        recent_releases = [
+            release
+            for release in (releases.json() if releases.status_code == 200 else [])
+            if (release.get("published_at") or "") >= year_cutoff
+        ]
+        return {
+            "org": company.github_org,
+            "stars_total": sum(repo.get("stargazers_count", 0) for repo in repos),
+            "top_repo": top.get("full_name"),
+            "top_repo_stars": top.get("stargazers_count", 0),
+            "contributors": (
+                len(contributors.json()) if contributors.status_code == 200 else None
+            ),
+            "releases_last_year": len(recent_releases),
+            "open_issues": top.get("open_issues_count"),
+        }
+
+
+class AppStoreConnector(EnrichmentConnector):
+    """Collects App Store ratings and review velocity for consumer apps.
+
+    Only runs for companies with ``app_store_id`` set. Uses Apple's public
+    lookup and review feeds; ranking history would need a paid provider.
+    """
+
+    name = "app_store"
+
+    LOOKUP_URL = "https://itunes.apple.com/lookup"
+    REVIEWS_URL = (
+        "https://itunes.apple.com/us/rss/customerreviews/id={app_id}"
+        "/sortBy=mostRecent/json"
+    )
+
+    def is_configured(self) -> bool:
+        # Apple's lookup API needs no key.
+        return True
+
+    def fetch(self, company: Company) -> dict[str, Any]:
+        if not company.app_store_id:
+            return {}
+        with httpx.Client(timeout=FETCH_TIMEOUT_SECONDS) as client:
+            response = client.get(
+                self.LOOKUP_URL, params={"id": company.app_store_id}
+            )
+            response.raise_for_status()
+            results = response.json().get("results", [])
+            if not results:
+                return {}
+            app = results[0]
+            reviews = client.get(
+                self.REVIEWS_URL.format(app_id=company.app_store_id)
+            )
+        month_cutoff = (utcnow() - timedelta(days=30)).isoformat()
+        recent_reviews = 0
+        if reviews.status_code == 200:
+            entries = reviews.json().get("feed", {}).get("entry", [])
+            recent_reviews = sum(
+                1
+                for entry in entries
+                if entry.get("updated", {}).get("label", "") >= month_cutoff
+            )
+        return {
+            "app_name": app.get("trackName"),
+            "rating": app.get("averageUserRating"),
+            "rating_count": app.get("userRatingCount"),
+            "genres": app.get("genres", [])[:3],
+            "reviews_last_30_days": recent_reviews,
+        }
+
+
+class SimilarWebConnector(EnrichmentConnector):
+    """Estimates web traffic (visits, growth, geography) for the company
+    site, feeding the market potential score and traction insights."""
+
+    name = "web_traffic"
+
+    API_URL = "https://api.similarweb.com/v1/website"
+
+    def is_configured(self) -> bool:
+        return bool(settings.SIMILARWEB_API_KEY)
+
+    def _get(self, client: httpx.Client, domain: str, path: str) -> dict[str, Any]:
+        response = client.get(
+            f"{self.API_URL}/{domain}/{path}",
+            params={
+                "api_key": settings.SIMILARWEB_API_KEY,
+                "granularity": "monthly",
+                "main_domain_only": "true",
+            },
+        )
+        response.raise_for_status()
+        return response.json()
+
+    def fetch(self, company: Company) -> dict[str, Any]:
+        if not company.website:
+            return {}
+        domain = website_domain(company.website)
+        if not domain:
+            return {}
+        with httpx.Client(timeout=FETCH_TIMEOUT_SECONDS) as client:
+            visits_payload = self._get(
+                client, domain, "total-traffic-and-engagement/visits"
+            )
+            try:
+                geo_payload = self._get(client, domain, "geo/traffic-by-country")
+            except httpx.HTTPError:
+                geo_payload = {}
+        visits = [
+            {"month": item.get("date", "")[:7], "visits": item.get("visits")}
+            for item in visits_payload.get("visits", [])
+        ]
+        growth = None
+        if len(visits) >= 2 and visits[0]["visits"]:
+            growth = round(
+                (visits[-1]["visits"] - visits[0]["visits"]) / visits[0]["visits"],
+                3,
+            )
+        return {
+            "domain": domain,
+            "monthly_visits": visits,
+            "growth": growth,
+            "top_countries": [
+                {
+                    "country": item.get("country"),
+                    "share": item.get("share"),
+                }
+                for item in geo_payload.get("records", [])[:5]
+            ],
+        }
+
+
+class TwitterConnector(EnrichmentConnector):
+    """Collects follower count, posting cadence, and engagement from the
+    company's Twitter/X account.
+
+    LinkedIn company pages have no public API, so the social signal is
+    Twitter-only for now.
+    """
+
+    name = "social"
+
+    API_URL = "https://api.twitter.com/2"
+
+    def is_configured(self) -> bool:
+        return bool(settings.TWITTER_BEARER_TOKEN)
+
+    def fetch(self, company: Company) -> dict[str, Any]:
+        if not company.twitter_handle:
+            return {}
+        headers = {"Authorization": f"Bearer {settings.TWITTER_BEARER_TOKEN}"}
+        with httpx.Client(
+            timeout=FETCH_TIMEOUT_SECONDS, headers=headers
+        ) as client:
+            response = client.get(
+                f"{self.API_URL}/users/by/username/{company.twitter_handle}",
+                params={"user.fields": "public_metrics"},
+            )
+            response.raise_for_status()
+            user = response.json().get("data", {})
+            if not user:
+                return {}
+            tweets_response = client.get(
+                f"{self.API_URL}/users/{user['id']}/tweets",
+                params={
+                    "max_results": 20,
+                    "tweet.fields": "created_at,public_metrics",
+                },
+            )
+        metrics = user.get("public_metrics", {})
+        tweets = (
+            tweets_response.json().get("data", [])
+            if tweets_response.status_code == 200
+            else []
+        )
+        posts_per_week = None
+        if len(tweets) >= 2:
+            newest = tweets[0].get("created_at", "")[:10]
+            oldest = tweets[-1].get("created_at", "")[:10]
+            try:
+                span = (
+                    datetime.fromisoformat(newest) - datetime.fromisoformat(oldest)
+                ).days
+                posts_per_week = (
+                    round(len(tweets) * 7 / span, 1) if span > 0 else float(len(tweets))
+                )
+            except ValueError:
+                pass
+        engagement = [
+            (tweet.get("public_metrics", {}).get("like_count", 0))
+            + (tweet.get("public_metrics", {}).get("retweet_count", 0))
+            for tweet in tweets
+        ]
+        return {
+            "handle": company.twitter_handle,
+            "followers": metrics.get("followers_count"),
+            "posts_per_week": posts_per_week,
+            "avg_engagement": (
+                round(sum(engagement) / len(engagement), 1) if engagement else None
+            ),
+        }
+
+
+# Connectors run in order during the enrichment stage; unconfigured ones
+# are skipped. New providers register here.
+ENRICHMENT_CONNECTORS: list[EnrichmentConnector] = [
+    CrunchbaseConnector(),
+    PatentsViewConnector(),
+    GitHubConnector(),
+    AppStoreConnector(),
+    SimilarWebConnector(),
+    TwitterConnector(),
+]
+
+
+# LinkedIn data is fetched through Proxycurl; LinkedIn itself has no
+# public profile API.
+PROXYCURL_API_URL = "https://nubela.co/proxycurl/api/v2/linkedin"
+
+
+def fetch_linkedin_profile(linkedin_url: str) -> dict[str, Any] | None:
+    """
+    Fetch a founder's work history and education from their LinkedIn
+    profile via the configured data provider. Returns None when the
+    provider is unconfigured or the profile could not be fetched.
+    """
+    if not settings.PROXYCURL_API_KEY:
+        return None
+    try:
+        with httpx.Client(
+            timeout=FETCH_TIMEOUT_SECONDS,
+            headers={"Authorization": f"Bearer {settings.PROXYCURL_API_KEY}"},
+        ) as client:
+            response = client.get(PROXYCURL_API_URL, params={"url": linkedin_url})
+            response.raise_for_status()
+            payload = response.json()
+    except httpx.HTTPError as e:
+        logger.warning("LinkedIn enrichment of %s failed: %s", linkedin_url, e)
+        return None
+    return {
+        "headline": payload.get("headline"),
+        "experiences": [
+            {
+                "title": item.get("title"),
+                "company": item.get("company"),
+                "starts_at": (item.get("starts_at") or {}).get("year"),
+                "ends_at": (item.get("ends_at") or {}).get("year"),
+            }
+            for item in payload.get("experiences", [])
+        ],
+        "education": [
+            {
+                "school": item.get("school"),
+                "degree": item.get("degree_name"),
+                "field_of_study": item.get("field_of_study"),
+            }
+            for item in payload.get("education", [])
+        ],
+    }
@@ -0,0 +1,42 @@
+"""Pluggable error reporting for crashes outside the request cycle.
+
+Unhandled request errors surface as 500s, but the analysis pipeline,
+upload processing, and the background loops swallow exceptions into log
+lines that nobody watches. ``capture_exception`` forwards those crashes
+to Sentry when a DSN is configured — and is a silent no-op otherwise —
+tagging each event with the current request ID plus whatever context the
+call site attaches (analysis IDs, upload IDs) so an alert traces back to
+the work that crashed.
+"""
+
+import logging
+from typing import Any
+
+import sentry_sdk
+
+from app.core import tracing
+from app.core.config import settings
+
+logger = logging.getLogger(__name__)
+
+
+def is_configured() -> bool:
+    # Mirrors the sentry_sdk.init guard in app.main
+    return settings.SENTRY_DSN is not None and settings.ENVIRONMENT != "local"
+
+
+def capture_exception(exc: BaseException, **context: Any) -> None:
+    """Report an exception with context tags; no-op without a Sentry DSN."""
+    if not is_configured():
+        return
+    try:
+        with sentry_sdk.push_scope() as scope:
+            request_id = tracing.get_request_id()
+            if request_id:
+                scope.set_tag("request_id", request_id)
+            for key, value in context.items():
+                scope.set_tag(key, str(value))
+            sentry_sdk.capture_exception(exc)
+    except Exception:
+        # Reporting is best-effort; the crash is already in the logs
+        logger.exception("Failed to report exception to Sentry")
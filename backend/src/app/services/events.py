@@ -0,0 +1,45 @@
+import asyncio
+from collections import defaultdict
+from typing import Any
+
+
+class EventBroker:
+    """In-process pub/sub used to fan out progress events to connected clients.
+
+    Topics are plain strings (e.g. ``analysis:{id}``). Each subscriber gets its
+    own queue, so a slow consumer never blocks the publisher or other
+    subscribers.
+    """
+
+    def __init__(self) -> None:
+        self._subscribers: dict[str, set[asyncio.Queue[dict[str, Any]]]] = (
+            defaultdict(set)
+        )
+
+    def subscribe(self, topic: str) -> asyncio.Queue[dict[str, Any]]:
+        queue: asyncio.Queue[dict[str, Any]] = asyncio.Queue()
+        self._subscribers[topic].add(queue)
+        return queue
+
+    def unsubscribe(self, topic: str, queue: asyncio.Queue[dict[str, Any]]) -> None:
+        self._subscribers[topic].discard(queue)
+        if not self._subscribers[topic]:
+            del self._subscribers[topic]
+
+    def publish(self, topic: str, event: dict[str, Any]) -> None:
+        for queue in self._subscribers.get(topic, set()):
+            queue.put_nowait(event)
+
+
+broker = EventBroker()
+
+
+def workspace_topic(*, organization_id: Any | None, user_id: Any) -> str:
+    """Topic carrying all workspace events a user should see.
+
+    Members of an organization share one topic; users without an organization
+    get a personal one.
+    """
+    if organization_id is not None:
+        return f"workspace:org:{organization_id}"
+    return f"workspace:user:{user_id}"
@@ -0,0 +1,78 @@
+from typing import Any
+
+from sqlmodel import Session, col, func, select
+
+from app.models import Company, ExitComp
+
+# How many individual comparables the landscape lists; aggregates are
+# computed over the full industry set.
+MAX_COMPS = 10
+
+
+def _median(values: list[float]) -> float | None:
+    if not values:
+        return None
+    ordered = sorted(values)
+    mid = len(ordered) // 2
+    if len(ordered) % 2:
+        return ordered[mid]
+    return round((ordered[mid - 1] + ordered[mid]) / 2, 2)
+
+
+def compile_comparables(
+    session: Session, company: Company
+) -> dict[str, Any] | None:
+    """Comparable exits in the company's sector: recent deals, the median
+    revenue multiple, and which acquirers keep showing up. None when the
+    company has no industry or the dataset has no matches."""
+    if not company.industry:
+        return None
+    comps = list(
+        session.exec(
+            select(ExitComp)
+            .where(func.lower(ExitComp.industry) == company.industry.lower())
+            .order_by(col(ExitComp.exited_on).desc())
+        ).all()
+    )
+    if not comps:
+        return None
+    # Same-stage exits are the closest read on the likely outcome, so they
+    # lead the list.
+    if company.stage is not None:
+        comps.sort(key=lambda comp: comp.stage != company.stage)
+    by_type: dict[str, int] = {}
+    acquirer_counts: dict[str, int] = {}
+    for comp in comps:
+        by_type[comp.exit_type.value] = by_type.get(comp.exit_type.value, 0) + 1
+        if comp.acquirer:
+            acquirer_counts[comp.acquirer] = (
+                acquirer_counts.get(comp.acquirer, 0) + 1
+            )
+    repeat_acquirers = sorted(
+        (name for name, count in acquirer_counts.items() if count > 1),
+        key=lambda name: acquirer_counts[name],
+        reverse=True,
+    )
+    return {
+        "comps": [
+            {
+                "company_name": comp.company_name,
+                "exit_type": comp.exit_type.value,
+                "acquirer": comp.acquirer,
+                "exit_value_usd": comp.exit_value_usd,
+                "revenue_multiple": comp.revenue_multiple,
+                "exited_on": comp.exited_on.isoformat(),
+            }
+            for comp in comps[:MAX_COMPS]
+        ],
+        "exit_count": len(comps),
+        "median_revenue_multiple": _median(
+            [
+                comp.revenue_multiple
+                for comp in comps
+                if comp.revenue_multiple is not None
+            ]
+        ),
+        "by_type": by_type,
+        "repeat_acquirers": repeat_acquirers[:5],
+    }
@@ -0,0 +1,125 @@
+import csv
+import io
+
+from app.models import Analysis, Company
+
+
+def recommendation(score: float | None) -> str:
+    """Human-readable verdict for a score, shared by exports and notifications."""
+    if score is None:
+        return "No score"
+    if score >= 7.5:
+        return "Strong candidate"
+    if score >= 5.0:
+        return "Worth a closer look"
+    return "Pass"
+
+
+def analysis_recommendation(analysis: Analysis) -> str:
+    """The verdict consumers should show: an analyst's recorded override
+    when there is one, otherwise the score-derived recommendation. An
+    unresolved sanctions hit trumps both — no score argues past it."""
+    if analysis.results.get("compliance", {}).get("blocked"):
+        return "Blocked by compliance screening"
+    override = analysis.results.get("recommendation_override")
+    if override:
+        return str(override)
+    return recommendation(analysis.score)
+
+
+def build_analyses_csv(rows: list[tuple[Analysis, Company]]) -> bytes:
+    """Flatten analyses into one CSV row each, for spreadsheet work.
+
+    Per-metric score columns are the union across all rows so the header is
+    stable regardless of which metrics an individual analysis produced.
+    """
+    metric_keys = sorted(
+        {
+            key
+            for analysis, _ in rows
+            for key in analysis.results.get("scores", {})
+            if key != "overall"
+        }
+    )
+    buffer = io.StringIO()
+    writer = csv.writer(buffer)
+    writer.writerow(
+        [
+            "company",
+            "analyzed_at",
+            "score",
+            *(f"score_{key}" for key in metric_keys),
+            "recommendation",
+        ]
+    )
+    for analysis, company in rows:
+        scores = analysis.results.get("scores", {})
+        writer.writerow(
+            [
+                company.name,
+                analysis.completed_at.isoformat() if analysis.completed_at else "",
+                analysis.score if analysis.score is not None else "",
+                *(scores.get(key, "") for key in metric_keys),
+                analysis_recommendation(analysis),
+            ]
+        )
+    return buffer.getvalue().encode()
+
+
+def build_portfolio_xlsx(
+    companies: list[Company], rows: list[tuple[Analysis, Company]]
+) -> bytes:
+    """Build a multi-sheet portfolio workbook: companies, analyses, metrics,
+    and risks, one row per fact so the sheets pivot cleanly in Excel."""
+    from openpyxl import Workbook
+
+    workbook = Workbook()
+
+    companies_sheet = workbook.active
+    companies_sheet.title = "Companies"
+    companies_sheet.append(["Name", "Industry", "Stage", "Website", "Created"])
+    for company in companies:
+        companies_sheet.append(
+            [
+                company.name,
+                company.industry or "",
+                company.stage.value if company.stage else "",
+                company.website or "",
+                company.created_at.isoformat() if company.created_at else "",
+            ]
+        )
+
+    analyses_sheet = workbook.create_sheet("Analyses")
+    analyses_sheet.append(["Company", "Date", "Score", "Recommendation"])
+    metrics_sheet = workbook.create_sheet("Metrics")
+    metrics_sheet.append(["Company", "Date", "Metric", "Value"])
+    risks_sheet = workbook.create_sheet("Risks")
+    risks_sheet.append(["Company", "Date", "Category", "Description"])
+    for analysis, company in rows:
+        date = analysis.completed_at.isoformat() if analysis.completed_at else ""
+        analyses_sheet.append(
+            [
+                company.name,
+                date,
+                analysis.score if analysis.score is not None else "",
+                analysis_recommendation(analysis),
+            ]
+        )
+        for metric, value in analysis.results.get("scores", {}).items():
+            metrics_sheet.append([company.name, date, metric, value])
+        for risk in analysis.results.get("risks", []):
+            if isinstance(risk, dict):
+                risks_sheet.append(
+                    [
+                        company.name,
+                        date,
+                        risk.get("category", ""),
+                        risk.get("description", ""),
+                    ]
+                )
+            else:
+                risks_sheet.append([company.name, date, "", str(risk)])
+
+    buffer = io.BytesIO()
+    workbook.save(buffer)
+    return buffer.getvalue()
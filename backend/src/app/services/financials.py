@@ -0,0 +1,159 @@
+"""Burn rate and runway extraction from financial documents.
+
+Scans the extracted text of a company's uploaded financials for monthly
+burn and cash-position figures and derives the runway, so they land as
+structured fields on the analysis instead of being buried in prose.
+"""
+
+import re
+
+# "$250k", "$1.2m", "$1,200,000", "250,000 USD"
+_AMOUNT = r"[$€£]?\s?(\d[\d,]*(?:\.\d+)?)\s*([kKmM](?:illion)?|[bB](?:illion)?)?"
+
+_BURN_RE = re.compile(
+    rf"(?:monthly\s+)?(?:net\s+)?burn(?:\s+rate)?\D{{0,30}}{_AMOUNT}",
+    re.IGNORECASE,
+)
+_CASH_RE = re.compile(
+    rf"cash\s+(?:position|balance|on\s+hand|in\s+bank)\D{{0,30}}{_AMOUNT}",
+    re.IGNORECASE,
+)
+_ARR_RE = re.compile(
+    rf"(?:ARR|annual\s+recurring\s+revenue|annual\s+revenue)\D{{0,30}}{_AMOUNT}",
+    re.IGNORECASE,
+)
+
+_MULTIPLIERS = {"k": 1_000, "m": 1_000_000, "b": 1_000_000_000}
+
+
+def _parse_amount(number: str, suffix: str | None) -> float:
+    value = float(number.replace(",", ""))
+    if suffix:
+        value *= _MULTIPLIERS[suffix[0].lower()]
+    return value
+
+
+def _first_amount(pattern: re.Pattern[str], text: str) -> float | None:
+    match = pattern.search(text)
+    if not match:
+        return None
+    return _parse_amount(match.group(1), match.group(2))
+
+
+_CAC_RE = re.compile(
+    rf"(?:CAC|customer\s+acquisition\s+cost)\D{{0,30}}{_AMOUNT}", re.IGNORECASE
+)
+_LTV_RE = re.compile(
+    rf"(?:LTV|lifetime\s+value)\D{{0,30}}{_AMOUNT}", re.IGNORECASE
+)
+_MARGIN_RE = re.compile(
+    r"gross\s+margin\D{0,30}(\d{1,3}(?:\.\d+)?)\s*%", re.IGNORECASE
+)
+_PAYBACK_RE = re.compile(
+    r"payback\s+(?:period\s+)?\D{0,20}(\d{1,3}(?:\.\d+)?)\s*months?", re.IGNORECASE
+)
+
+
+def extract_unit_economics(text: str) -> dict[str, float]:
+    """
+    Pull CAC, LTV, gross margin, and payback period out of document text.
+    Returns only the inputs that were found.
+    """
+    inputs: dict[str, float] = {}
+    cac = _first_amount(_CAC_RE, text)
+    if cac:
+        inputs["cac_usd"] = cac
+    ltv = _first_amount(_LTV_RE, text)
+    if ltv:
+        inputs["ltv_usd"] = ltv
+    margin = _MARGIN_RE.search(text)
+    if margin:
+        inputs["gross_margin_pct"] = float(margin.group(1))
+    payback = _PAYBACK_RE.search(text)
+    if payback:
+        inputs["payback_months"] = float(payback.group(1))
+    return inputs
+
+
+# LTV/CAC ratios below which the cohort is considered weak; software
+# margins support higher acquisition spend than consumer or hardware.
+_HEALTHY_LTV_CAC = {"default": 3.0, "consumer": 2.5, "hardware": 2.0}
+
+
+def _industry_key(industry: str | None) -> str:
+    lowered = (industry or "").lower()
+    for key in ("consumer", "hardware"):
+        if key in lowered:
+            return key
+    return "default"
+
+
+def compute_unit_economics(
+    inputs: dict[str, float], industry: str | None = None
+) -> dict[str, object]:
+    """
+    Derive unit-economics metrics from the available inputs, with
+    industry-contextual commentary on what they imply.
+    """
+    metrics = dict(inputs)
+    commentary: list[str] = []
+    cac = inputs.get("cac_usd")
+    ltv = inputs.get("ltv_usd")
+    if cac and ltv:
+        ratio = round(ltv / cac, 2)
+        metrics["ltv_cac_ratio"] = ratio
+        healthy = _HEALTHY_LTV_CAC[_industry_key(industry)]
+        if ratio >= healthy:
+            commentary.append(
+                f"LTV/CAC of {ratio} clears the ~{healthy}x bar typical for "
+                f"this category."
+            )
+        else:
+            commentary.append(
+                f"LTV/CAC of {ratio} is below the ~{healthy}x bar typical "
+                f"for this category; acquisition spend may not pay back."
+            )
+    margin = inputs.get("gross_margin_pct")
+    if margin is not None:
+        if margin >= 70:
+            commentary.append(
+                f"Gross margin of {margin:g}% is software-grade."
+            )
+        elif margin < 40:
+            commentary.append(
+                f"Gross margin of {margin:g}% leaves little room for "
+                f"acquisition spend at scale."
+            )
+    payback = inputs.get("payback_months")
+    if payback is not None:
+        if payback <= 12:
+            commentary.append(
+                f"CAC payback of {payback:g} months is within the 12-month "
+                f"window most funds look for."
+            )
+        else:
+            commentary.append(
+                f"CAC payback of {payback:g} months ties up working capital "
+                f"longer than the usual 12-month target."
+            )
+    return {"metrics": metrics, "commentary": commentary}
+
+
+def extract_financials(text: str) -> dict[str, float]:
+    """
+    Pull monthly burn and cash on hand out of document text and compute
+    the runway in months. Returns only the figures that were found.
+    """
+    figures: dict[str, float] = {}
+    burn = _first_amount(_BURN_RE, text)
+    if burn:
+        figures["monthly_burn_usd"] = burn
+    cash = _first_amount(_CASH_RE, text)
+    if cash:
+        figures["cash_on_hand_usd"] = cash
+    if burn and cash:
+        figures["runway_months"] = round(cash / burn, 1)
+    arr = _first_amount(_ARR_RE, text)
+    if arr:
+        figures["arr_usd"] = arr
+    return figures
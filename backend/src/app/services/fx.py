@@ -0,0 +1,105 @@
+"""Currency detection and FX normalization.
+
+Financial documents arrive in whatever currency the founders work in.
+This module detects the dominant currency in a document, fetches a spot
+rate from the ECB-backed Frankfurter API (no key required), and converts
+extracted monetary values to the organization's base currency, recording
+the rate and date used alongside the figures.
+"""
+
+import logging
+import re
+from typing import Any
+
+import httpx
+
+from app.models import CurrencyConfig, Organization
+
+logger = logging.getLogger(__name__)
+
+FX_API_URL = "https://api.frankfurter.app/latest"
+FETCH_TIMEOUT_SECONDS = 10
+
+CURRENCY_SYMBOLS = {"$": "USD", "€": "EUR", "£": "GBP"}
+CURRENCY_CODES = ("USD", "EUR", "GBP", "CHF", "JPY", "CAD", "AUD", "SEK", "INR")
+
+# Spot rates cached per (from, to) for the process lifetime; precision
+# beyond the day is irrelevant for screening.
+_rate_cache: dict[tuple[str, str], tuple[float, str]] = {}
+
+
+def get_currency_config(organization: Organization | None) -> CurrencyConfig:
+    if organization is None:
+        return CurrencyConfig()
+    return CurrencyConfig.model_validate(organization.settings.get("currency", {}))
+
+
+def detect_currency(text: str) -> str | None:
+    """
+    Pick the dominant currency in a document from its symbols and ISO
+    codes. Returns None when no currency marker appears.
+    """
+    counts: dict[str, int] = {}
+    for symbol, code in CURRENCY_SYMBOLS.items():
+        counts[code] = counts.get(code, 0) + text.count(symbol)
+    for code in CURRENCY_CODES:
+        counts[code] = counts.get(code, 0) + len(
+            re.findall(rf"\b{code}\b", text)
+        )
+    best = max(counts, key=lambda code: counts[code])
+    return best if counts[best] > 0 else None
+
+
+def get_rate(from_code: str, to_code: str) -> tuple[float, str] | None:
+    """
+    Spot rate from one currency to another, with the quote date. Returns
+    None when the provider is unreachable or the pair is unknown.
+    """
+    if from_code == to_code:
+        return 1.0, ""
+    cached = _rate_cache.get((from_code, to_code))
+    if cached:
+        return cached
+    try:
+        with httpx.Client(timeout=FETCH_TIMEOUT_SECONDS) as client:
+            response = client.get(
+                FX_API_URL, params={"from": from_code, "to": to_code}
+            )
+            response.raise_for_status()
+            payload = response.json()
+    except httpx.HTTPError as e:
+        logger.warning("FX rate %s->%s failed: %s", from_code, to_code, e)
+        return None
+    rate = payload.get("rates", {}).get(to_code)
+    if rate is None:
+        return None
+    result = (float(rate), payload.get("date", ""))
+    _rate_cache[(from_code, to_code)] = result
+    return result
+
+
+def normalize_figures(
+    figures: dict[str, float], from_code: str, to_code: str
+) -> dict[str, Any]:
+    """
+    Convert every monetary figure (ratio-like keys pass through) to the
+    base currency and attach the conversion metadata. Returns the figures
+    unchanged when no rate is available.
+    """
+    if from_code == to_code:
+        return dict(figures)
+    quote = get_rate(from_code, to_code)
+    if quote is None:
+        return dict(figures)
+    rate, date = quote
+    converted: dict[str, Any] = {
+        key: round(value * rate, 2) if key.endswith("_usd") else value
+        for key, value in figures.items()
+    }
+    converted["currency"] = {
+        "detected": from_code,
+        "base": to_code,
+        "rate": rate,
+        "rate_date": date,
+    }
+    return converted
@@ -0,0 +1,111 @@
+from typing import Any
+
+from app.models import Company
+
+# Jurisdiction traits keyed by lowercase market code (ISO country codes,
+# plus "eu" for companies selling into the bloc as a whole). Each entry
+# lists the concern categories the market raises; anything not listed here
+# contributes no findings. Deliberately coarse — this flags jurisdictions
+# for an analyst to dig into, it does not replace counsel.
+JURISDICTIONS: dict[str, dict[str, Any]] = {
+    "eu": {
+        "label": "European Union",
+        "data_residency": "GDPR applies; transfers outside the EEA need a lawful mechanism",
+    },
+    "gb": {
+        "label": "United Kingdom",
+        "data_residency": "UK GDPR applies; adequacy with the EU is revocable",
+    },
+    "cn": {
+        "label": "China",
+        "data_residency": "PIPL and the Data Security Law require in-country storage for many categories",
+        "licensing": "ICP licence required to operate consumer internet services",
+    },
+    "ru": {
+        "label": "Russia",
+        "data_residency": "Personal data of citizens must be stored on servers in Russia",
+        "sanctions": "Broad sanctions regimes restrict transactions and investment",
+    },
+    "in": {
+        "label": "India",
+        "data_residency": "DPDP Act restricts cross-border transfer of personal data",
+        "licensing": "Payment and lending products require RBI authorization",
+    },
+    "br": {
+        "label": "Brazil",
+        "data_residency": "LGPD governs processing and cross-border transfers",
+    },
+    "ir": {
+        "label": "Iran",
+        "sanctions": "Comprehensive sanctions; most transactions are prohibited",
+    },
+    "kp": {
+        "label": "North Korea",
+        "sanctions": "Comprehensive sanctions; most transactions are prohibited",
+    },
+    "cu": {
+        "label": "Cuba",
+        "sanctions": "Comprehensive sanctions; most transactions are prohibited",
+    },
+    "sy": {
+        "label": "Syria",
+        "sanctions": "Comprehensive sanctions; most transactions are prohibited",
+    },
+    "by": {
+        "label": "Belarus",
+        "sanctions": "Broad sanctions regimes restrict transactions and investment",
+    },
+}
+
+# Industries where operating licences are the norm in most markets, not
+# just the ones flagged per-jurisdiction above.
+LICENSED_INDUSTRIES = {"fintech", "insurance", "healthcare", "banking", "crypto"}
+
+# How much each finding category raises the 0-10 risk score.
+CATEGORY_WEIGHTS = {"sanctions": 4.0, "data_residency": 1.5, "licensing": 1.5}
+
+
+def assess(company: Company) -> dict[str, Any] | None:
+    """Jurisdiction-specific risks for the company's markets: data
+    residency obligations, licensing requirements, and sanctions exposure.
+    None when the company lists no markets."""
+    if not company.markets:
+        return None
+    industry = (company.industry or "").lower()
+    markets = []
+    score = 0.0
+    for code in company.markets:
+        code = code.strip().lower()
+        traits = JURISDICTIONS.get(code, {})
+        concerns = []
+        for category in ("sanctions", "data_residency", "licensing"):
+            if category in traits:
+                concerns.append(
+                    {"category": category, "description": traits[category]}
+                )
+                score += CATEGORY_WEIGHTS[category]
+        if "licensing" not in traits and industry in LICENSED_INDUSTRIES:
+            concerns.append(
+                {
+                    "category": "licensing",
+                    "description": f"{industry.title()} products typically require local authorization",
+                }
+            )
+            score += CATEGORY_WEIGHTS["licensing"]
+        markets.append(
+            {
+                "market": code,
+                "label": traits.get("label", code.upper()),
+                "concerns": concerns,
+            }
+        )
+    return {
+        "markets": markets,
+        "sanctions_exposure": any(
+            concern["category"] == "sanctions"
+            for entry in markets
+            for concern in entry["concerns"]
+        ),
+        # Higher is riskier; capped so one bad market does not saturate it
+        "risk_score": round(min(score, 10.0), 2),
+    }
@@ -0,0 +1,90 @@
+from datetime import date, datetime
+
+SUPPORTED_LOCALES = ("en", "de", "fr")
+
+# Catalog-based translation of the fixed strings reports emit. Generated
+# prose (memo text, risk descriptions) is passed through unchanged until the
+# pipeline itself produces localized output.
+_CATALOGS: dict[str, dict[str, str]] = {
+    "de": {
+        "Investment Analysis": "Investitionsanalyse",
+        "Overall score": "Gesamtbewertung",
+        "Company profile": "Unternehmensprofil",
+        "Key metrics": "Kennzahlen",
+        "Funding timeline": "Finanzierungshistorie",
+        "Scenarios": "Szenarien",
+        "Bull": "Optimistisch",
+        "Base": "Basis",
+        "Bear": "Pessimistisch",
+        "Exit landscape": "Exit-Landschaft",
+        "Acquisition": "Übernahme",
+        "IPO": "Börsengang",
+        "Summary": "Zusammenfassung",
+        "Insights": "Erkenntnisse",
+        "Risks": "Risiken",
+        "Industry": "Branche",
+        "Stage": "Phase",
+        "Website": "Webseite",
+        "Generated by": "Erstellt von",
+        "Strong candidate": "Starker Kandidat",
+        "Worth a closer look": "Genauer ansehen",
+        "Pass": "Ablehnen",
+        "No score": "Keine Bewertung",
+    },
+    "fr": {
+        "Investment Analysis": "Analyse d'investissement",
+        "Overall score": "Score global",
+        "Company profile": "Profil de l'entreprise",
+        "Key metrics": "Indicateurs clés",
+        "Funding timeline": "Historique de financement",
+        "Scenarios": "Scénarios",
+        "Bull": "Optimiste",
+        "Base": "Central",
+        "Bear": "Pessimiste",
+        "Exit landscape": "Panorama des sorties",
+        "Acquisition": "Acquisition",
+        "IPO": "Introduction en bourse",
+        "Summary": "Résumé",
+        "Insights": "Observations",
+        "Risks": "Risques",
+        "Industry": "Secteur",
+        "Stage": "Phase",
+        "Website": "Site web",
+        "Generated by": "Généré par",
+        "Strong candidate": "Candidat solide",
+        "Worth a closer look": "À examiner de plus près",
+        "Pass": "Écarter",
+        "No score": "Pas de score",
+    },
+}
+
+# Locales where the decimal separator is a comma and dates are day-first.
+_COMMA_DECIMAL = ("de", "fr")
+
+
+class Translator:
+    """Translates catalog strings and formats numbers/dates for one locale."""
+
+    def __init__(self, locale: str = "en"):
+        self.locale = locale
+        self._catalog = _CATALOGS.get(locale, {})
+
+    def __call__(self, text: str) -> str:
+        return self._catalog.get(text, text)
+
+    def format_number(self, value: float | int | str) -> str:
+        if not isinstance(value, int | float):
+            return str(value)
+        text = f"{value:g}"
+        if self.locale in _COMMA_DECIMAL:
+            return text.replace(".", ",")
+        return text
+
+    def format_date(self, value: datetime | date) -> str:
+        if self.locale in _COMMA_DECIMAL:
+            return value.strftime("%d.%m.%Y")
+        return value.strftime("%Y-%m-%d")
+
+
+def get_translator(locale: str = "en") -> Translator:
+    return Translator(locale if locale in SUPPORTED_LOCALES else "en")
@@ -0,0 +1,138 @@
+"""News and press sentiment for companies under analysis.
+
+Pulls recent articles mentioning the company from NewsAPI, scores each
+headline with a small sentiment lexicon, and condenses them into a
+monthly sentiment trend plus a list of notable events (funding,
+acquisitions, launches, layoffs, lawsuits). The pipeline folds these into
+the analysis insights.
+"""
+
+import logging
+import re
+from datetime import timedelta
+from typing import Any
+
+import httpx
+
+from app.core.config import settings
+from app.models import utcnow
+
+logger = logging.getLogger(__name__)
+
+NEWSAPI_URL = "https://newsapi.org/v2/everything"
+FETCH_TIMEOUT_SECONDS = 10
+LOOKBACK_DAYS = 90
+MAX_ARTICLES = 50
+
+# Deliberately small lexicons; headlines are short and a rough
+# positive/negative split is all the trend needs.
+_POSITIVE_WORDS = {
+    "raises",
+    "funding",
+    "growth",
+    "launches",
+    "expands",
+    "partnership",
+    "acquires",
+    "milestone",
+    "record",
+    "wins",
+}
+_NEGATIVE_WORDS = {
+    "layoffs",
+    "lawsuit",
+    "breach",
+    "shutdown",
+    "losses",
+    "fraud",
+    "decline",
+    "recall",
+    "fine",
+    "outage",
+}
+
+NOTABLE_KEYWORDS = {
+    "funding": ("raises", "funding", "series"),
+    "acquisition": ("acquires", "acquired", "merger"),
+    "launch": ("launches", "launch", "unveils"),
+    "layoffs": ("layoffs", "lays off"),
+    "legal": ("lawsuit", "sues", "settlement"),
+}
+
+
+def _sentiment(text: str) -> int:
+    words = set(re.findall(r"[a-z]+", text.lower()))
+    positive = len(words & _POSITIVE_WORDS)
+    negative = len(words & _NEGATIVE_WORDS)
+    if positive > negative:
+        return 1
+    if negative > positive:
+        return -1
+    return 0
+
+
+def _notable_kind(title: str) -> str | None:
+    lowered = title.lower()
+    for kind, keywords in NOTABLE_KEYWORDS.items():
+        if any(keyword in lowered for keyword in keywords):
+            return kind
+    return None
+
+
+def gather_news(company_name: str) -> dict[str, Any] | None:
+    """
+    Fetch and condense recent press about a company. Returns None when
+    NewsAPI is unconfigured or unreachable.
+    """
+    if not settings.NEWSAPI_API_KEY:
+        return None
+    since = (utcnow() - timedelta(days=LOOKBACK_DAYS)).date().isoformat()
+    try:
+        with httpx.Client(timeout=FETCH_TIMEOUT_SECONDS) as client:
+            response = client.get(
+                NEWSAPI_URL,
+                params={
+                    "q": f'"{company_name}"',
+                    "from": since,
+                    "sortBy": "publishedAt",
+                    "pageSize": MAX_ARTICLES,
+                },
+                headers={"X-Api-Key": settings.NEWSAPI_API_KEY},
+            )
+            response.raise_for_status()
+            articles = response.json().get("articles", [])
+    except httpx.HTTPError as e:
+        logger.warning("News lookup for %s failed: %s", company_name, e)
+        return None
+    by_month: dict[str, list[int]] = {}
+    notable_events = []
+    for article in articles:
+        title = article.get("title") or ""
+        published = (article.get("publishedAt") or "")[:7]
+        if published:
+            by_month.setdefault(published, []).append(_sentiment(title))
+        kind = _notable_kind(title)
+        if kind:
+            notable_events.append(
+                {
+                    "kind": kind,
+                    "title": title,
+                    "date": (article.get("publishedAt") or "")[:10],
+                    "url": article.get("url"),
+                }
+            )
+    scores = [score for month in by_month.values() for score in month]
+    return {
+        "article_count": len(articles),
+        "average_sentiment": (
+            round(sum(scores) / len(scores), 2) if scores else 0.0
+        ),
+        "sentiment_trend": [
+            {
+                "month": month,
+                "sentiment": round(sum(values) / len(values), 2),
+            }
+            for month, values in sorted(by_month.items())
+        ],
+        "notable_events": notable_events[:10],
+    }
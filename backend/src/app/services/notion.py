@@ -0,0 +1,93 @@
+import httpx
+from fastapi import HTTPException
+from sqlmodel import Session
+
+from app.models import Analysis, Company, NotionConfig, Organization
+from app.services.exports import analysis_recommendation
+from app.services.reports import compose_sections
+
+NOTION_API_URL = "https://api.notion.com/v1/pages"
+NOTION_VERSION = "2022-06-28"
+POST_TIMEOUT_SECONDS = 15
+
+
+def get_notion_config(organization: Organization) -> NotionConfig:
+    return NotionConfig.model_validate(organization.settings.get("notion", {}))
+
+
+def _rich_text(content: str) -> list[dict[str, object]]:
+    # Notion caps rich text content at 2000 characters per block.
+    return [{"type": "text", "text": {"content": content[:2000]}}]
+
+
+def _memo_blocks(analysis: Analysis, company: Company) -> list[dict[str, object]]:
+    blocks: list[dict[str, object]] = []
+    for section in compose_sections(analysis, company):
+        blocks.append(
+            {
+                "object": "block",
+                "type": "heading_2",
+                "heading_2": {"rich_text": _rich_text(section["title"])},
+            }
+        )
+        if section["kind"] == "table" and section["data"]:
+            lines = [f"{label}: {value}" for label, value in section["data"]]
+        elif section["kind"] == "scores" and section["data"]:
+            lines = [
+                f"{metric.replace('_', ' ')}: {value}"
+                for metric, value in section["data"].items()
+            ]
+        elif section["kind"] == "text" and section["data"]:
+            lines = [section["data"]]
+        elif section["kind"] == "list":
+            lines = section["data"] or []
+        else:
+            lines = []
+        for line in lines:
+            blocks.append(
+                {
+                    "object": "block",
+                    "type": "paragraph",
+                    "paragraph": {"rich_text": _rich_text(str(line))},
+                }
+            )
+    return blocks
+
+
+async def export_memo(
+    session: Session, organization: Organization, analysis: Analysis
+) -> str:
+    """Push the analysis memo into the organization's Notion database and
+    return the URL of the created page."""
+    config = get_notion_config(organization)
+    if not config.enabled or not config.api_key or not config.database_id:
+        raise HTTPException(
+            status_code=409, detail="Notion integration is not configured"
+        )
+    company = session.get(Company, analysis.company_id)
+    if not company:
+        raise HTTPException(status_code=404, detail="Company not found")
+    payload = {
+        "parent": {"database_id": config.database_id},
+        "properties": {
+            "Name": {
+                "title": _rich_text(f"{company.name} — Investment Analysis")
+            },
+            "Score": {"number": analysis.score},
+            "Recommendation": {
+                "rich_text": _rich_text(analysis_recommendation(analysis))
+            },
+        },
+        "children": _memo_blocks(analysis, company),
+    }
+    headers = {
+        "Authorization": f"Bearer {config.api_key}",
+        "Notion-Version": NOTION_VERSION,
+    }
+    try:
+        async with httpx.AsyncClient(timeout=POST_TIMEOUT_SECONDS) as client:
+            response = await client.post(NOTION_API_URL, json=payload, headers=headers)
+            response.raise_for_status()
+    except httpx.HTTPError as e:
+        raise HTTPException(status_code=502, detail=f"Notion export failed: {e}")
+    return str(response.json().get("url", ""))
@@ -0,0 +1,226 @@
+"""GDPR tooling: machine-readable exports and verified erasure.
+
+Exports serialize through the Public models so secrets (password hashes,
+webhook signing keys) never leave the database. Erasure removes a
+company's documents and every derived artifact — extracted pages, stored
+files, analyses, vector index jobs, cached idempotent responses — then
+re-queries each table to prove the data is gone.
+"""
+
+import json
+from typing import Any
+
+from fastapi.encoders import jsonable_encoder
+from sqlmodel import Session, col, func, select
+
+from app.models import (
+    Analysis,
+    AnalysisPublic,
+    AnalysisSchedule,
+    AnalysisTag,
+    Company,
+    CompanyPublic,
+    CompanyTag,
+    Founder,
+    FounderPublic,
+    FundingRound,
+    FundingRoundPublic,
+    IdempotencyKey,
+    Item,
+    ItemPublic,
+    Organization,
+    OrganizationPublic,
+    PortfolioCompany,
+    UploadedFile,
+    UploadedFilePublic,
+    User,
+    UserPublic,
+    VectorIndexJob,
+    utcnow,
+)
+from app.services import storage
+
+
+def _company_export(session: Session, company: Company) -> dict[str, Any]:
+    analyses = session.exec(
+        select(Analysis).where(Analysis.company_id == company.id)
+    ).all()
+    uploads = session.exec(
+        select(UploadedFile).where(UploadedFile.company_id == company.id)
+    ).all()
+    return {
+        "company": jsonable_encoder(CompanyPublic.model_validate(company)),
+        "founders": [
+            jsonable_encoder(FounderPublic.model_validate(founder))
+            for founder in company.founders
+        ],
+        "funding_rounds": [
+            jsonable_encoder(FundingRoundPublic.model_validate(round))
+            for round in company.funding_rounds
+        ],
+        "analyses": [
+            jsonable_encoder(AnalysisPublic.model_validate(analysis))
+            for analysis in analyses
+        ],
+        "uploads": [
+            jsonable_encoder(UploadedFilePublic.model_validate(upload))
+            for upload in uploads
+        ],
+    }
+
+
+def export_organization(
+    session: Session, organization: Organization
+) -> dict[str, Any]:
+    """Everything the platform holds for an org, one JSON document."""
+    users = session.exec(
+        select(User).where(User.organization_id == organization.id)
+    ).all()
+    user_ids = [user.id for user in users]
+    companies = session.exec(
+        select(Company).where(col(Company.owner_id).in_(user_ids))
+    ).all()
+    return {
+        "exported_at": utcnow().isoformat(),
+        "organization": jsonable_encoder(
+            OrganizationPublic.model_validate(organization)
+        ),
+        "users": [
+            jsonable_encoder(UserPublic.model_validate(user)) for user in users
+        ],
+        "companies": [
+            _company_export(session, company) for company in companies
+        ],
+    }
+
+
+def export_user(session: Session, user: User) -> dict[str, Any]:
+    """Everything the platform holds for one person."""
+    companies = session.exec(
+        select(Company).where(Company.owner_id == user.id)
+    ).all()
+    items = session.exec(select(Item).where(Item.owner_id == user.id)).all()
+    return {
+        "exported_at": utcnow().isoformat(),
+        "user": jsonable_encoder(UserPublic.model_validate(user)),
+        "items": [
+            jsonable_encoder(ItemPublic.model_validate(item)) for item in items
+        ],
+        "companies": [
+            _company_export(session, company) for company in companies
+        ],
+    }
+
+
+def _count(session: Session, model: Any, condition: Any) -> int:
+    return session.exec(
+        select(func.count()).select_from(model).where(condition)
+    ).one()
+
+
+def erase_company(session: Session, company: Company) -> dict[str, Any]:
+    """Permanently erase a company and everything derived from it, and
+    return a verification report proving each table is clean."""
+    company_id = company.id
+    erased: dict[str, int] = {}
+
+    uploads = session.exec(
+        select(UploadedFile).where(UploadedFile.company_id == company_id)
+    ).all()
+    erased["stored_files"] = 0
+    erased["upload_pages"] = 0
+    for upload in uploads:
+        storage.delete_file(upload.storage_path)
+        erased["stored_files"] += 1
+        for page in upload.pages:
+            if page.image_path:
+                storage.delete_file(page.image_path)
+            erased["upload_pages"] += 1
+    erased["uploads"] = len(uploads)
+
+    analyses = session.exec(
+        select(Analysis).where(Analysis.company_id == company_id)
+    ).all()
+    analysis_ids = [analysis.id for analysis in analyses]
+    erased["analyses"] = len(analyses)
+    analysis_tags = (
+        session.exec(
+            select(AnalysisTag).where(
+                col(AnalysisTag.analysis_id).in_(analysis_ids)
+            )
+        ).all()
+        if analysis_ids
+        else []
+    )
+    for link in analysis_tags:
+        session.delete(link)
+
+    erased["founders"] = len(company.founders)
+    erased["funding_rounds"] = len(company.funding_rounds)
+    for rows in (
+        session.exec(
+            select(CompanyTag).where(CompanyTag.company_id == company_id)
+        ).all(),
+        session.exec(
+            select(PortfolioCompany).where(
+                PortfolioCompany.company_id == company_id
+            )
+        ).all(),
+        session.exec(
+            select(AnalysisSchedule).where(
+                AnalysisSchedule.company_id == company_id
+            )
+        ).all(),
+        session.exec(
+            select(VectorIndexJob).where(
+                VectorIndexJob.company_id == company_id
+            )
+        ).all(),
+    ):
+        for row in rows:
+            session.delete(row)
+
+    # Cached idempotent responses can embed the erased records verbatim;
+    # a substring match over the JSON catches them without a schema.
+    cached = [
+        entry
+        for entry in session.exec(select(IdempotencyKey)).all()
+        if str(company_id) in json.dumps(entry.response_body)
+    ]
+    erased["cached_responses"] = len(cached)
+    for entry in cached:
+        session.delete(entry)
+
+    # Deleting the row cascades uploads, pages, analyses, founders, and
+    # funding rounds at the database level.
+    session.delete(company)
+    session.commit()
+
+    verification = {
+        "company": session.get(Company, company_id) is None,
+        "uploads": _count(
+            session, UploadedFile, UploadedFile.company_id == company_id
+        )
+        == 0,
+        "analyses": _count(
+            session, Analysis, Analysis.company_id == company_id
+        )
+        == 0,
+        "founders": _count(session, Founder, Founder.company_id == company_id)
+        == 0,
+        "funding_rounds": _count(
+            session, FundingRound, FundingRound.company_id == company_id
+        )
+        == 0,
+        "vector_index_jobs": _count(
+            session, VectorIndexJob, VectorIndexJob.company_id == company_id
+        )
+        == 0,
+    }
+    return {
+        "company_id": str(company_id),
+        "erased": erased,
+        "verification": verification,
+        "verified": all(verification.values()),
+        "completed_at": utcnow().isoformat(),
+    }
@@ -0,0 +1,40 @@
+import uuid
+
+from sqlmodel import Session, col, select
+
+from app.models import Analysis, AnalysisStatus, Company, QueuePause, User
+
+
+def is_paused(session: Session, organization_id: uuid.UUID | None) -> bool:
+    """Check whether job consumption is paused globally or for an org."""
+    statement = select(QueuePause).where(col(QueuePause.organization_id).is_(None))
+    if session.exec(statement).first():
+        return True
+    if organization_id is None:
+        return False
+    statement = select(QueuePause).where(
+        QueuePause.organization_id == organization_id
+    )
+    return session.exec(statement).first() is not None
+
+
+def organization_for_analysis(
+    session: Session, analysis: Analysis
+) -> uuid.UUID | None:
+    company = session.get(Company, analysis.company_id)
+    owner = session.get(User, company.owner_id) if company else None
+    return owner.organization_id if owner else None
+
+
+def pending_analyses(
+    session: Session, organization_id: uuid.UUID | None
+) -> list[Analysis]:
+    """Pending analyses in scope; all of them when no organization is given."""
+    statement = select(Analysis).where(Analysis.status == AnalysisStatus.PENDING)
+    if organization_id is not None:
+        statement = (
+            statement.join(Company, col(Analysis.company_id) == col(Company.id))
+            .join(User, col(Company.owner_id) == col(User.id))
+            .where(User.organization_id == organization_id)
+        )
+    return list(session.exec(statement).all())
@@ -0,0 +1,30 @@
+import re
+
+# Deliberately pattern-based: redaction runs over every page of a data
+# room, so it must be cheap and dependency-free. Patterns favour recall
+# over precision — masking a stray match costs little next to leaking
+# personal data to an external provider.
+EMAIL_RE = re.compile(r"[\w.+-]+@[\w-]+\.[\w.-]+")
+SSN_RE = re.compile(r"\b\d{3}-\d{2}-\d{4}\b")
+PHONE_RE = re.compile(
+    r"(?<![\d/.])"
+    r"(?:\+\d{1,3}[ .-]?)?(?:\(\d{2,4}\)[ .-]?)?\d{3}[ .-]\d{3,4}(?:[ .-]\d{4})?"
+    r"(?![\d/])"
+)
+# A named individual's pay figure, e.g. "Jane Doe salary: $180,000"; the
+# name stays, the amount goes.
+SALARY_RE = re.compile(
+    r"([A-Z][a-z]+(?: [A-Z][a-z]+)+(?:'s)?[ ,:–-]*"
+    r"(?:base |annual |total )?(?:salary|compensation|comp|pay)[ ,:–-]*(?:of )?)"
+    r"([$€£]\s?\d[\d,.]*[kKmM]?|\d[\d,.]*\s?(?:USD|EUR|GBP))"
+)
+
+
+def redact_text(text: str) -> str:
+    """Mask emails, phone numbers, SSNs, and named individuals' pay so the
+    text is safe to send to an external LLM or embeddings provider."""
+    text = EMAIL_RE.sub("[REDACTED EMAIL]", text)
+    text = SSN_RE.sub("[REDACTED SSN]", text)
+    text = PHONE_RE.sub("[REDACTED PHONE]", text)
+    text = SALARY_RE.sub(lambda m: f"{m.group(1)}[REDACTED AMOUNT]", text)
+    return text
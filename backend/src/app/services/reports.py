@@ -0,0 +1,409 @@
+import base64
+import html
+import io
+import uuid
+from pathlib import Path
+from typing import Any
+
+from jinja2 import Template
+from reportlab.graphics.charts.barcharts import VerticalBarChart
+from reportlab.graphics.shapes import Drawing
+from reportlab.lib import colors
+from reportlab.lib.pagesizes import A4
+from reportlab.lib.styles import getSampleStyleSheet
+from reportlab.lib.units import cm
+from reportlab.platypus import (
+    Image,
+    Paragraph,
+    SimpleDocTemplate,
+    Spacer,
+    Table,
+    TableStyle,
+)
+from sqlmodel import Session, select
+
+from app.core.config import settings
+from app.models import (
+    Analysis,
+    BrandingConfig,
+    Company,
+    Organization,
+    ReportTemplate,
+)
+from app.services import storage
+from app.services.i18n import Translator, get_translator
+
+# Used when the organization has no active template.
+DEFAULT_SECTIONS: list[dict[str, Any]] = [
+    {"key": "profile", "title": "Company profile"},
+    {"key": "timeline", "title": "Funding timeline"},
+    {"key": "metrics", "title": "Key metrics"},
+    {"key": "summary", "title": "Summary"},
+    {"key": "scenarios", "title": "Scenarios"},
+    {"key": "exits", "title": "Exit landscape"},
+    {"key": "insights", "title": "Insights"},
+    {"key": "risks", "title": "Risks"},
+]
+
+
+def get_active_template(
+    session: Session, organization_id: uuid.UUID | None
+) -> ReportTemplate | None:
+    if organization_id is None:
+        return None
+    statement = select(ReportTemplate).where(
+        ReportTemplate.organization_id == organization_id,
+        ReportTemplate.is_active == True,  # noqa: E712
+    )
+    return session.exec(statement).first()
+
+
+def get_branding_config(organization: Organization) -> BrandingConfig:
+    return BrandingConfig.model_validate(organization.settings.get("branding", {}))
+
+
+def get_branding(
+    session: Session, organization_id: uuid.UUID | None
+) -> BrandingConfig:
+    if organization_id is None:
+        return BrandingConfig()
+    organization = session.get(Organization, organization_id)
+    if not organization:
+        return BrandingConfig()
+    return get_branding_config(organization)
+
+
+def _logo_bytes(branding: BrandingConfig) -> bytes | None:
+    if not branding.logo_path:
+        return None
+    try:
+        return storage.read_file(branding.logo_path)
+    except OSError:
+        return None
+
+
+def _risk_lines(analysis: Analysis) -> list[str]:
+    lines = []
+    for risk in analysis.results.get("risks", []):
+        if isinstance(risk, dict):
+            lines.append(
+                f"{risk.get('category', 'risk')}: {risk.get('description', '')}"
+            )
+        else:
+            lines.append(str(risk))
+    return lines
+
+
+def _insight_lines(analysis: Analysis) -> list[str]:
+    return [
+        insight if isinstance(insight, str) else insight.get("text", "")
+        for insight in analysis.results.get("insights", [])
+    ]
+
+
+def _timeline_rows(company: Company, tr: Translator) -> list[list[str]]:
+    rows = []
+    for funding_round in sorted(
+        company.funding_rounds, key=lambda r: r.announced_on
+    ):
+        amount = (
+            f"${funding_round.amount_usd:,.0f}"
+            if funding_round.amount_usd is not None
+            else "—"
+        )
+        rows.append(
+            [
+                tr.format_date(funding_round.announced_on),
+                funding_round.stage.value,
+                amount,
+                ", ".join(funding_round.investors) or "—",
+            ]
+        )
+    return rows
+
+
+def _scenario_rows(analysis: Analysis, tr: Translator) -> list[list[str]]:
+    rows = []
+    for scenario in analysis.results.get("scenarios", []):
+        outcomes = scenario.get("outcomes", {})
+        arr = outcomes.get("projected_arr_usd")
+        runway = outcomes.get("runway_months")
+        rows.append(
+            [
+                tr(scenario.get("name", "").capitalize()),
+                f"${arr:,.0f}" if arr is not None else "—",
+                tr.format_number(runway) if runway is not None else "—",
+            ]
+        )
+    return rows
+
+
+def _exit_rows(analysis: Analysis, tr: Translator) -> list[list[str]]:
+    landscape = analysis.results.get("exit_landscape", {})
+    rows = []
+    for comp in landscape.get("comps", []):
+        value = comp.get("exit_value_usd")
+        multiple = comp.get("revenue_multiple")
+        rows.append(
+            [
+                comp.get("company_name", ""),
+                tr("IPO") if comp.get("exit_type") == "ipo" else tr("Acquisition"),
+                comp.get("acquirer") or "—",
+                f"${value:,.0f}" if value is not None else "—",
+                f"{multiple:.1f}x" if multiple is not None else "—",
+            ]
+        )
+    return rows
+
+
+def compose_sections(
+    analysis: Analysis,
+    company: Company,
+    template: ReportTemplate | None = None,
+    tr: Translator | None = None,
+) -> list[dict[str, Any]]:
+    """Resolve the section plan (template or default) into renderable blocks.
+
+    Every block has a title, optional boilerplate, a kind (table, scores,
+    text, or list), and data; format-specific builders only decide how each
+    kind looks.
+    """
+    tr = tr or Translator()
+    plan = template.sections if template and template.sections else DEFAULT_SECTIONS
+    memo = analysis.results.get("memo", {})
+    content: dict[str, tuple[str, Any]] = {
+        "profile": (
+            "table",
+            [
+                [tr("Industry"), company.industry or "—"],
+                [tr("Stage"), company.stage.value if company.stage else "—"],
+                [tr("Website"), company.website or "—"],
+            ],
+        ),
+        "timeline": ("table", _timeline_rows(company, tr)),
+        "metrics": ("scores", analysis.results.get("scores", {})),
+        "summary": ("text", memo.get("summary")),
+        "scenarios": ("table", _scenario_rows(analysis, tr)),
+        "exits": ("table", _exit_rows(analysis, tr)),
+        "insights": ("list", _insight_lines(analysis)),
+        "risks": ("list", _risk_lines(analysis)),
+    }
+    blocks = []
+    for section in plan:
+        key = section.get("key")
+        kind, data = content.get(key, ("text", None))
+        if not data and not section.get("boilerplate"):
+            continue
+        blocks.append(
+            {
+                "title": tr(section.get("title") or str(key)),
+                "boilerplate": section.get("boilerplate"),
+                "kind": kind,
+                "data": data,
+            }
+        )
+    return blocks
+
+
+def _score_chart(scores: dict[str, Any], fill_color: str) -> Drawing:
+    numeric = {k: v for k, v in scores.items() if isinstance(v, int | float)}
+    drawing = Drawing(400, 180)
+    chart = VerticalBarChart()
+    chart.x = 40
+    chart.y = 30
+    chart.width = 320
+    chart.height = 130
+    chart.data = [list(numeric.values()) or [0]]
+    chart.categoryAxis.categoryNames = [
+        k.replace("_", " ") for k in numeric
+    ] or ["n/a"]
+    chart.valueAxis.valueMin = 0
+    chart.valueAxis.valueMax = 10
+    chart.bars[0].fillColor = colors.HexColor(fill_color)
+    drawing.add(chart)
+    return drawing
+
+
+def build_pdf_report(
+    analysis: Analysis,
+    company: Company,
+    template: ReportTemplate | None = None,
+    branding: BrandingConfig | None = None,
+    locale: str = "en",
+) -> bytes:
+    """Render an analysis as a PDF report."""
+    branding = branding or BrandingConfig()
+    tr = get_translator(locale)
+    title = f"{company.name} — {tr('Investment Analysis')}"
+    score = (
+        tr.format_number(analysis.score) if analysis.score is not None else "n/a"
+    )
+    buffer = io.BytesIO()
+    doc = SimpleDocTemplate(buffer, pagesize=A4, title=title)
+    styles = getSampleStyleSheet()
+    story: list[Any] = []
+    logo = _logo_bytes(branding)
+    if logo:
+        story.append(Image(io.BytesIO(logo), width=4 * cm, height=1.5 * cm))
+        story.append(Spacer(1, 0.3 * cm))
+    story += [
+        Paragraph(title, styles["Title"]),
+        Spacer(1, 0.3 * cm),
+        Paragraph(f"{tr('Overall score')}: {score}", styles["Heading2"]),
+        Spacer(1, 0.3 * cm),
+    ]
+    if company.description:
+        story.append(Paragraph(company.description, styles["Normal"]))
+        story.append(Spacer(1, 0.3 * cm))
+    for block in compose_sections(analysis, company, template, tr):
+        story.append(Paragraph(block["title"], styles["Heading3"]))
+        if block["boilerplate"]:
+            story.append(Paragraph(block["boilerplate"], styles["Italic"]))
+        if block["kind"] == "table" and block["data"]:
+            table = Table(block["data"], colWidths=[4 * cm, 10 * cm])
+            table.setStyle(
+                TableStyle(
+                    [
+                        ("FONTNAME", (0, 0), (0, -1), "Helvetica-Bold"),
+                        ("FONTSIZE", (0, 0), (-1, -1), 9),
+                        ("GRID", (0, 0), (-1, -1), 0.25, colors.grey),
+                        ("VALIGN", (0, 0), (-1, -1), "TOP"),
+                    ]
+                )
+            )
+            story.append(table)
+        elif block["kind"] == "scores" and block["data"]:
+            story.append(_score_chart(block["data"], branding.primary_color))
+        elif block["kind"] == "text" and block["data"]:
+            story.append(Paragraph(block["data"], styles["Normal"]))
+        elif block["kind"] == "list":
+            for line in block["data"] or []:
+                story.append(Paragraph(f"• {line}", styles["Normal"]))
+        story.append(Spacer(1, 0.5 * cm))
+    if branding.footer_text:
+        story.append(Paragraph(branding.footer_text, styles["Italic"]))
+    if analysis.trace_id:
+        story.append(Paragraph(f"Trace ID: {analysis.trace_id}", styles["Normal"]))
+    doc.build(story)
+    return buffer.getvalue()
+
+
+def build_docx_report(
+    analysis: Analysis,
+    company: Company,
+    template: ReportTemplate | None = None,
+    branding: BrandingConfig | None = None,
+    locale: str = "en",
+) -> bytes:
+    """Render an analysis as an editable Word document."""
+    import docx
+    from docx.shared import Cm
+
+    branding = branding or BrandingConfig()
+    tr = get_translator(locale)
+    score = (
+        tr.format_number(analysis.score) if analysis.score is not None else "n/a"
+    )
+    document = docx.Document()
+    logo = _logo_bytes(branding)
+    if logo:
+        document.add_picture(io.BytesIO(logo), width=Cm(4))
+    document.add_heading(f"{company.name} — {tr('Investment Analysis')}", level=0)
+    document.add_heading(f"{tr('Overall score')}: {score}", level=2)
+    if company.description:
+        document.add_paragraph(company.description)
+    for block in compose_sections(analysis, company, template, tr):
+        document.add_heading(block["title"], level=3)
+        if block["boilerplate"]:
+            document.add_paragraph(block["boilerplate"])
+        if block["kind"] == "table" and block["data"]:
+            table = document.add_table(rows=0, cols=2)
+            for label, value in block["data"]:
+                row = table.add_row()
+                row.cells[0].text = label
+                row.cells[1].text = value
+        elif block["kind"] == "scores" and block["data"]:
+            for metric, value in block["data"].items():
+                document.add_paragraph(
+                    f"{metric.replace('_', ' ')}: {value}", style="List Bullet"
+                )
+        elif block["kind"] == "text" and block["data"]:
+            document.add_paragraph(block["data"])
+        elif block["kind"] == "list":
+            for line in block["data"] or []:
+                document.add_paragraph(line, style="List Bullet")
+    if branding.footer_text:
+        document.add_paragraph(branding.footer_text)
+    buffer = io.BytesIO()
+    document.save(buffer)
+    return buffer.getvalue()
+
+
+def _render_report_template(*, template_name: str, context: dict[str, Any]) -> str:
+    template_str = (
+        Path(__file__).parents[1] / "report-templates" / template_name
+    ).read_text()
+    return Template(template_str).render(context)
+
+
+def _svg_score_chart(scores: dict[str, Any], fill_color: str) -> str:
+    numeric = {k: v for k, v in scores.items() if isinstance(v, int | float)}
+    if not numeric:
+        return ""
+    bar_height = 24
+    width = 600
+    parts = [
+        f'<svg xmlns="http://www.w3.org/2000/svg" width="{width}" '
+        f'height="{len(numeric) * (bar_height + 8)}">'
+    ]
+    for i, (metric, value) in enumerate(numeric.items()):
+        y = i * (bar_height + 8)
+        bar = int(max(0.0, min(float(value), 10.0)) / 10 * (width - 220))
+        label = html.escape(metric.replace("_", " "))
+        parts.append(
+            f'<text x="0" y="{y + 16}" font-size="12">{label}</text>'
+            f'<rect x="160" y="{y}" width="{bar}" height="{bar_height}" fill="{fill_color}"/>'
+            f'<text x="{165 + bar}" y="{y + 16}" font-size="12">{value}</text>'
+        )
+    parts.append("</svg>")
+    return "".join(parts)
+
+
+def build_html_report(
+    analysis: Analysis,
+    company: Company,
+    template: ReportTemplate | None = None,
+    branding: BrandingConfig | None = None,
+    locale: str = "en",
+) -> bytes:
+    """Render an analysis as a standalone HTML page with embedded charts."""
+    branding = branding or BrandingConfig()
+    tr = get_translator(locale)
+    blocks = []
+    for block in compose_sections(analysis, company, template, tr):
+        rendered = dict(block)
+        if block["kind"] == "scores":
+            rendered["chart_svg"] = _svg_score_chart(
+                block["data"] or {}, branding.primary_color
+            )
+        blocks.append(rendered)
+    logo = _logo_bytes(branding)
+    content = _render_report_template(
+        template_name="analysis_report.html",
+        context={
+            "project_name": settings.PROJECT_NAME,
+            "company": company,
+            "report_title": tr("Investment Analysis"),
+            "score_label": tr("Overall score"),
+            "generated_by": tr("Generated by"),
+            "score": tr.format_number(analysis.score)
+            if analysis.score is not None
+            else "n/a",
+            "blocks": blocks,
+            "trace_id": analysis.trace_id,
+            "brand_color": branding.primary_color,
+            "footer_text": branding.footer_text,
+            "logo_data": base64.b64encode(logo).decode() if logo else None,
+        },
+    )
+    return content.encode()
@@ -0,0 +1,87 @@
+from sqlmodel import Session, col, select
+
+from app.models import (
+    Analysis,
+    AnalysisStatus,
+    Company,
+    ReserveRecommendationPublic,
+    ReserveRequest,
+)
+
+# How the latest score scales the reserve: double down on winners, keep
+# dry powder off the laggards.
+STRONG_SCORE = 7.0
+WEAK_SCORE = 4.0
+LOW_RUNWAY_MONTHS = 6.0
+
+
+def recommend_reserve(
+    session: Session, company: Company, params: ReserveRequest
+) -> ReserveRecommendationPublic:
+    """Recommend follow-on reserve sizing for an invested company from its
+    latest performance, expected dilution, and the fund's concentration
+    limit."""
+    latest = session.exec(
+        select(Analysis)
+        .where(
+            Analysis.company_id == company.id,
+            Analysis.status == AnalysisStatus.COMPLETED,
+        )
+        .order_by(col(Analysis.completed_at).desc())
+    ).first()
+    rationale = []
+    # Holding ownership flat through n rounds at dilution d means buying
+    # 1/(1-d)^n - 1 of the original position again.
+    retention = (1 - params.expected_dilution) ** params.rounds_ahead
+    pro_rata = params.initial_check_usd * (1 / retention - 1)
+    rationale.append(
+        f"Pro-rata through {params.rounds_ahead} round(s) at "
+        f"{params.expected_dilution:.0%} dilution each needs "
+        f"${pro_rata:,.0f}"
+    )
+    multiplier = 1.0
+    if latest is None or latest.score is None:
+        rationale.append("No completed analysis; holding reserve at pro-rata")
+    elif latest.score >= STRONG_SCORE:
+        multiplier = 1.25
+        rationale.append(
+            f"Latest score {latest.score:.1f} is strong; reserving above pro-rata"
+        )
+    elif latest.score < WEAK_SCORE:
+        multiplier = 0.5
+        rationale.append(
+            f"Latest score {latest.score:.1f} is weak; reserving below pro-rata"
+        )
+    else:
+        rationale.append(f"Latest score {latest.score:.1f} supports pro-rata")
+    recommended = pro_rata * multiplier
+    cap = (
+        params.fund_size_usd * params.max_position_pct / 100
+        - params.initial_check_usd
+    )
+    if recommended > cap:
+        recommended = max(cap, 0.0)
+        rationale.append(
+            f"Capped by the {params.max_position_pct:.0f}% concentration limit"
+        )
+    priority = "medium"
+    if (
+        latest
+        and latest.runway_months is not None
+        and latest.runway_months < LOW_RUNWAY_MONTHS
+    ):
+        priority = "high"
+        rationale.append(
+            f"Runway of {latest.runway_months:.1f} months suggests a raise "
+            "is imminent"
+        )
+    elif multiplier < 1.0 or recommended == 0.0:
+        priority = "low"
+    return ReserveRecommendationPublic(
+        company_id=company.id,
+        recommended_reserve_usd=round(recommended, 2),
+        pro_rata_usd=round(pro_rata, 2),
+        performance_multiplier=multiplier,
+        priority=priority,
+        rationale=rationale,
+    )
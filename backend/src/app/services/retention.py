@@ -0,0 +1,116 @@
+"""Configurable data retention with automatic purge.
+
+Each organization can set retention rules (see ``RetentionConfig``); a
+daily background loop purges uploads — stored files, page images, and
+extracted text — once a rule's window has elapsed. The preview endpoint
+runs the same eligibility logic without deleting anything, so admins can
+see exactly what a policy would remove before enabling it.
+"""
+
+import asyncio
+import logging
+from datetime import timedelta
+
+from sqlmodel import Session, col, select
+
+from app.core.db import engine
+from app.models import (
+    Company,
+    Organization,
+    PipelineStage,
+    RetentionConfig,
+    UploadedFile,
+    User,
+    utcnow,
+)
+from app.services import error_reporting, storage
+
+logger = logging.getLogger(__name__)
+
+RETENTION_POLL_SECONDS = 60 * 60 * 24
+
+
+def get_retention_config(organization: Organization) -> RetentionConfig:
+    return RetentionConfig.model_validate(
+        organization.settings.get("retention", {})
+    )
+
+
+def eligible_uploads(
+    session: Session, organization: Organization
+) -> list[UploadedFile]:
+    """Uploads the org's retention policy says to purge, oldest first."""
+    config = get_retention_config(organization)
+    if config.upload_days is None and config.passed_upload_days is None:
+        return []
+    now = utcnow()
+    rows = session.exec(
+        select(UploadedFile, Company)
+        .join(User, col(UploadedFile.owner_id) == col(User.id))
+        .join(
+            Company,
+            col(UploadedFile.company_id) == col(Company.id),
+            isouter=True,
+        )
+        .where(User.organization_id == organization.id)
+        .order_by(col(UploadedFile.created_at))
+    ).all()
+    eligible = []
+    for upload, company in rows:
+        created_at = upload.created_at
+        if created_at.tzinfo is None:
+            created_at = created_at.replace(tzinfo=now.tzinfo)
+        if config.upload_days is not None and created_at <= now - timedelta(
+            days=config.upload_days
+        ):
+            eligible.append(upload)
+            continue
+        if (
+            config.passed_upload_days is not None
+            and company is not None
+            and company.pipeline_stage == PipelineStage.PASSED
+            and company.stage_changed_at is not None
+        ):
+            passed_at = company.stage_changed_at
+            if passed_at.tzinfo is None:
+                passed_at = passed_at.replace(tzinfo=now.tzinfo)
+            if passed_at <= now - timedelta(days=config.passed_upload_days):
+                eligible.append(upload)
+    return eligible
+
+
+def purge_eligible(session: Session, organization: Organization) -> int:
+    """Delete everything the policy makes eligible; returns the count."""
+    uploads = eligible_uploads(session, organization)
+    for upload in uploads:
+        storage.delete_file(upload.storage_path)
+        for page in upload.pages:
+            if page.image_path:
+                storage.delete_file(page.image_path)
+        # Deleting the row cascades the extracted pages
+        session.delete(upload)
+    session.commit()
+    return len(uploads)
+
+
+def run_due_purges() -> None:
+    with Session(engine) as session:
+        for organization in session.exec(select(Organization)).all():
+            purged = purge_eligible(session, organization)
+            if purged:
+                logger.info(
+                    "Retention purged %d uploads for organization %s",
+                    purged,
+                    organization.id,
+                )
+
+
+async def retention_loop() -> None:
+    """Background loop enforcing retention; started from the app lifespan."""
+    while True:
+        try:
+            await asyncio.to_thread(run_due_purges)
+        except Exception as e:
+            logger.exception("Retention loop iteration failed")
+            error_reporting.capture_exception(e, loop="retention")
+        await asyncio.sleep(RETENTION_POLL_SECONDS)
@@ -0,0 +1,75 @@
+import asyncio
+import logging
+from datetime import timedelta
+
+from sqlmodel import Session, select
+
+from app.core.db import engine
+from app.models import (
+    Analysis,
+    AnalysisSchedule,
+    Company,
+    ScheduleFrequency,
+    utcnow,
+)
+from app.services import error_reporting
+from app.services.analysis import next_version, run_analysis
+
+logger = logging.getLogger(__name__)
+
+SCHEDULER_POLL_SECONDS = 60 * 60
+
+FREQUENCY_DAYS = {
+    ScheduleFrequency.WEEKLY: 7,
+    ScheduleFrequency.MONTHLY: 30,
+    ScheduleFrequency.QUARTERLY: 91,
+}
+
+
+def interval_for(frequency: ScheduleFrequency) -> timedelta:
+    return timedelta(days=FREQUENCY_DAYS[frequency])
+
+
+async def run_due_schedules() -> None:
+    """Start a fresh analysis for every schedule whose next run has arrived."""
+    now = utcnow()
+    with Session(engine) as session:
+        due = session.exec(
+            select(AnalysisSchedule).where(
+                AnalysisSchedule.enabled == True,  # noqa: E712
+                AnalysisSchedule.next_run_at <= now,  # type: ignore[arg-type]
+            )
+        ).all()
+        for schedule in due:
+            company = session.get(Company, schedule.company_id)
+            # Advance the schedule even when the company is gone so a
+            # deleted deal doesn't keep the schedule permanently due.
+            schedule.last_run_at = now
+            schedule.next_run_at = now + interval_for(schedule.frequency)
+            session.add(schedule)
+            if not company or company.deleted_at is not None:
+                session.commit()
+                continue
+            version, supersedes_id = next_version(session, company.id)
+            analysis = Analysis(
+                company_id=company.id,
+                requested_by=schedule.created_by,
+                version=version,
+                supersedes_id=supersedes_id,
+            )
+            session.add(analysis)
+            session.commit()
+            session.refresh(analysis)
+            await run_analysis(analysis.id)
+
+
+async def scheduler_loop() -> None:
+    """Background loop running periodic re-analyses; started from the app
+    lifespan."""
+    while True:
+        try:
+            await run_due_schedules()
+        except Exception as e:
+            logger.exception("Scheduler loop iteration failed")
+            error_reporting.capture_exception(e, loop="scheduler")
+        await asyncio.sleep(SCHEDULER_POLL_SECONDS)
@@ -0,0 +1,108 @@
+import uuid
+from typing import Any
+
+from sqlmodel import Session, select
+
+from app.models import ScoringModel
+
+# Operators a signal may test its resolved value with.
+SIGNAL_OPS = ("gte", "lte", "eq", "exists", "contains")
+
+
+def validate_definition(definition: dict[str, Any]) -> None:
+    """Check a scoring DSL document, raising ValueError on the first
+    problem so the API can reject bad models before they are stored."""
+    metrics = definition.get("metrics")
+    if not isinstance(metrics, dict) or not metrics:
+        raise ValueError("definition needs a non-empty 'metrics' mapping")
+    for name, spec in metrics.items():
+        if not isinstance(spec, dict):
+            raise ValueError(f"metric '{name}' must be a mapping")
+        base = spec.get("base", 5.0)
+        if not isinstance(base, int | float) or not 0 <= base <= 10:
+            raise ValueError(f"metric '{name}' base must be between 0 and 10")
+        signals = spec.get("signals", [])
+        if not isinstance(signals, list):
+            raise ValueError(f"metric '{name}' signals must be a list")
+        for signal in signals:
+            if not isinstance(signal, dict) or not signal.get("path"):
+                raise ValueError(f"metric '{name}' has a signal without a path")
+            op = signal.get("op", "exists")
+            if op not in SIGNAL_OPS:
+                raise ValueError(
+                    f"metric '{name}' uses unknown op '{op}'; "
+                    f"expected one of {', '.join(SIGNAL_OPS)}"
+                )
+            if op in ("gte", "lte", "eq", "contains") and "value" not in signal:
+                raise ValueError(f"metric '{name}' op '{op}' needs a value")
+            points = signal.get("points", 1.0)
+            if not isinstance(points, int | float):
+                raise ValueError(f"metric '{name}' has non-numeric points")
+    weights = definition.get("overall", {}).get("weights", {})
+    if not isinstance(weights, dict):
+        raise ValueError("overall.weights must be a mapping")
+    for metric, weight in weights.items():
+        if not isinstance(weight, int | float) or weight < 0:
+            raise ValueError(f"weight for '{metric}' must be non-negative")
+        if metric not in metrics:
+            raise ValueError(f"weight references undefined metric '{metric}'")
+
+
+def _resolve(results: dict[str, Any], path: str) -> Any:
+    value: Any = results
+    for part in path.split("."):
+        if not isinstance(value, dict):
+            return None
+        value = value.get(part)
+    return value
+
+
+def _signal_fires(signal: dict[str, Any], value: Any) -> bool:
+    op = signal.get("op", "exists")
+    if op == "exists":
+        return value is not None
+    if value is None:
+        return False
+    if op == "contains":
+        return str(signal["value"]).lower() in str(value).lower()
+    if not isinstance(value, int | float):
+        return False
+    if op == "gte":
+        return value >= signal["value"]
+    if op == "lte":
+        return value <= signal["value"]
+    return value == signal["value"]
+
+
+def evaluate(
+    definition: dict[str, Any], results: dict[str, Any]
+) -> tuple[dict[str, float], dict[str, float]]:
+    """Evaluate a scoring DSL document against pipeline results, returning
+    the metric scores and the overall weights it declares."""
+    scores = {}
+    for name, spec in definition.get("metrics", {}).items():
+        score = float(spec.get("base", 5.0))
+        for signal in spec.get("signals", []):
+            if _signal_fires(signal, _resolve(results, signal["path"])):
+                score += float(signal.get("points", 1.0))
+        scores[name] = max(0.0, min(10.0, round(score, 2)))
+    weights = {
+        metric: float(weight)
+        for metric, weight in definition.get("overall", {})
+        .get("weights", {})
+        .items()
+    }
+    return scores, weights
+
+
+def active_model(
+    session: Session, organization_id: uuid.UUID | None
+) -> ScoringModel | None:
+    if organization_id is None:
+        return None
+    return session.exec(
+        select(ScoringModel).where(
+            ScoringModel.organization_id == organization_id,
+            ScoringModel.is_active == True,  # noqa: E712
+        )
+    ).first()
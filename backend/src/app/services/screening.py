@@ -0,0 +1,89 @@
+"""Sanctions and PEP screening against the OpenSanctions dataset.
+
+Matches the company entity and its founders against consolidated
+sanctions and politically-exposed-person lists. Hits are surfaced as
+blocking red flags: regulated investors cannot wire money into a deal
+with an unresolved sanctions match, so these are meant to stop a
+recommendation, not just annotate it.
+"""
+
+import logging
+from typing import Any
+
+import httpx
+
+from app.core.config import settings
+from app.models import Company, Founder
+
+logger = logging.getLogger(__name__)
+
+MATCH_TIMEOUT_SECONDS = 15
+
+# Below this score a match is considered noise and dropped.
+MIN_MATCH_SCORE = 0.7
+
+# Topics that make a hit blocking rather than informational.
+BLOCKING_TOPICS = {"sanction", "sanction.linked", "crime", "crime.fin"}
+
+
+def is_configured() -> bool:
+    return bool(settings.OPENSANCTIONS_API_KEY)
+
+
+def _match_queries(
+    company: Company, founders: list[Founder]
+) -> dict[str, dict[str, Any]]:
+    queries: dict[str, dict[str, Any]] = {
+        "company": {"schema": "Company", "properties": {"name": [company.name]}}
+    }
+    for index, founder in enumerate(founders):
+        queries[f"founder_{index}"] = {
+            "schema": "Person",
+            "properties": {"name": [founder.name]},
+        }
+    return queries
+
+
+def _subject_name(key: str, company: Company, founders: list[Founder]) -> str:
+    if key == "company":
+        return company.name
+    return founders[int(key.split("_", 1)[1])].name
+
+
+def screen(company: Company, founders: list[Founder]) -> dict[str, Any] | None:
+    """Screen the entity and founders, returning hits and whether any of
+    them block the deal. None when screening is not configured."""
+    if not is_configured():
+        return None
+    queries = _match_queries(company, founders)
+    response = httpx.post(
+        "https://api.opensanctions.org/match/default",
+        json={"queries": queries},
+        headers={"Authorization": f"ApiKey {settings.OPENSANCTIONS_API_KEY}"},
+        timeout=MATCH_TIMEOUT_SECONDS,
+    )
+    response.raise_for_status()
+    responses = response.json().get("responses", {})
+    hits = []
+    for key, result in responses.items():
+        for match in result.get("results", []):
+            if match.get("score", 0.0) < MIN_MATCH_SCORE:
+                continue
+            topics = match.get("properties", {}).get("topics", [])
+            hits.append(
+                {
+                    "subject": _subject_name(key, company, founders),
+                    "subject_type": "company" if key == "company" else "founder",
+                    "matched_name": match.get("caption"),
+                    "score": match.get("score"),
+                    "topics": topics,
+                    "datasets": match.get("datasets", []),
+                    "blocking": bool(BLOCKING_TOPICS.intersection(topics)),
+                }
+            )
+    return {
+        "provider": "opensanctions",
+        "screened": [company.name, *(founder.name for founder in founders)],
+        "hits": hits,
+        "blocked": any(hit["blocking"] for hit in hits),
+    }
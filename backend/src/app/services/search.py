@@ -0,0 +1,94 @@
+import json
+
+from sqlalchemy import String, cast
+from sqlmodel import Session, col, or_, select
+
+from app.models import (
+    Analysis,
+    Company,
+    SearchHit,
+    SearchResultsPublic,
+    UploadedFile,
+    UploadPage,
+    User,
+)
+
+# Matches per entity group; search is a jumping-off point, not a browser.
+GROUP_LIMIT = 10
+
+SNIPPET_RADIUS = 60
+
+
+def _snippet(text: str, q: str) -> str | None:
+    index = text.lower().find(q.lower())
+    if index == -1:
+        return None
+    start = max(0, index - SNIPPET_RADIUS)
+    end = min(len(text), index + len(q) + SNIPPET_RADIUS)
+    prefix = "…" if start > 0 else ""
+    suffix = "…" if end < len(text) else ""
+    return f"{prefix}{text[start:end]}{suffix}"
+
+
+def search(session: Session, user: User, q: str) -> SearchResultsPublic:
+    """Case-insensitive substring search across the caller's companies,
+    analysis results, and extracted document text."""
+    pattern = f"%{q}%"
+
+    company_statement = select(Company).where(
+        col(Company.deleted_at).is_(None),
+        or_(
+            col(Company.name).ilike(pattern),
+            col(Company.description).ilike(pattern),
+        ),
+    )
+    analysis_statement = (
+        select(Analysis, Company)
+        .join(Company)
+        .where(
+            col(Company.deleted_at).is_(None),
+            cast(Analysis.results, String).ilike(pattern),
+        )
+    )
+    page_statement = (
+        select(UploadPage, UploadedFile)
+        .join(UploadedFile)
+        .where(col(UploadPage.text).ilike(pattern))
+    )
+    if not user.is_superuser:
+        company_statement = company_statement.where(Company.owner_id == user.id)
+        analysis_statement = analysis_statement.where(Company.owner_id == user.id)
+        page_statement = page_statement.where(UploadedFile.owner_id == user.id)
+
+    companies = [
+        SearchHit(
+            type="company",
+            id=company.id,
+            title=company.name,
+            snippet=_snippet(company.description or "", q),
+        )
+        for company in session.exec(company_statement.limit(GROUP_LIMIT)).all()
+    ]
+    analyses = [
+        SearchHit(
+            type="analysis",
+            id=analysis.id,
+            title=company.name,
+            snippet=_snippet(json.dumps(analysis.results), q),
+        )
+        for analysis, company in session.exec(
+            analysis_statement.limit(GROUP_LIMIT)
+        ).all()
+    ]
+    documents = [
+        SearchHit(
+            type="document",
+            id=upload.id,
+            title=upload.filename,
+            snippet=_snippet(page.text, q),
+        )
+        for page, upload in session.exec(page_statement.limit(GROUP_LIMIT)).all()
+    ]
+    return SearchResultsPublic(
+        companies=companies, analyses=analyses, documents=documents
+    )
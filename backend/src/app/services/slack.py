@@ -0,0 +1,68 @@
+import logging
+
+import httpx
+from sqlmodel import Session
+
+from app.core.config import settings
+from app.models import Analysis, Company, Organization, SlackConfig
+from app.services.exports import analysis_recommendation
+
+logger = logging.getLogger(__name__)
+
+POST_TIMEOUT_SECONDS = 10
+
+
+def get_slack_config(organization: Organization) -> SlackConfig:
+    return SlackConfig.model_validate(organization.settings.get("slack", {}))
+
+
+async def post_analysis_summary(
+    session: Session, organization_id: object | None, analysis: Analysis
+) -> None:
+    """Post a summary card for a completed analysis to the org's channel."""
+    if organization_id is None:
+        return
+    organization = session.get(Organization, organization_id)
+    if not organization:
+        return
+    config = get_slack_config(organization)
+    if not config.enabled or not config.webhook_url:
+        return
+    company = session.get(Company, analysis.company_id)
+    name = company.name if company else "Unknown company"
+    link = f"{settings.FRONTEND_HOST}/analysis/{analysis.id}"
+    message: dict[str, object] = {
+        "blocks": [
+            {
+                "type": "section",
+                "text": {
+                    "type": "mrkdwn",
+                    "text": f"*{name}* analysis completed",
+                },
+            },
+            {
+                "type": "section",
+                "fields": [
+                    {"type": "mrkdwn", "text": f"*Score:*\n{analysis.score}"},
+                    {
+                        "type": "mrkdwn",
+                        "text": f"*Recommendation:*\n{analysis_recommendation(analysis)}",
+                    },
+                ],
+            },
+            {
+                "type": "section",
+                "text": {"type": "mrkdwn", "text": f"<{link}|View full report>"},
+            },
+        ]
+    }
+    if config.channel:
+        message["channel"] = config.channel
+    try:
+        async with httpx.AsyncClient(timeout=POST_TIMEOUT_SECONDS) as client:
+            response = await client.post(config.webhook_url, json=message)
+            response.raise_for_status()
+    except httpx.HTTPError as e:
+        logger.warning(
+            "Slack notification for analysis %s failed: %s", analysis.id, e
+        )
@@ -0,0 +1,26 @@
+import uuid
+from pathlib import Path
+
+from app.core.config import settings
+
+
+def _root() -> Path:
+    root = Path(settings.UPLOADS_DIR)
+    root.mkdir(parents=True, exist_ok=True)
+    return root
+
+
+def save_upload(filename: str, content: bytes) -> str:
+    """Store file content and return its storage path."""
+    suffix = Path(filename).suffix
+    path = _root() / f"{uuid.uuid4().hex}{suffix}"
+    path.write_bytes(content)
+    return str(path)
+
+
+def read_file(storage_path: str) -> bytes:
+    return Path(storage_path).read_bytes()
+
+
+def delete_file(storage_path: str) -> None:
+    Path(storage_path).unlink(missing_ok=True)
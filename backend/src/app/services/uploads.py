@@ -0,0 +1,214 @@
+import io
+import logging
+import uuid
+
+from sqlmodel import Session
+
+from app.core.db import engine
+from app.models import FileCategory, UploadedFile, UploadPage, UploadStatus, User
+from app.services import antivirus, error_reporting, storage, webhooks
+from app.services.bus import publish_domain_event
+from app.services.events import broker, workspace_topic
+
+logger = logging.getLogger(__name__)
+
+# Plain-text fallback pagination when the format has no page structure.
+TEXT_PAGE_CHARS = 3000
+
+# Magic-byte signatures checked in order; first prefix match wins. ZIP
+# covers the modern Office formats, OLE the legacy ones.
+MAGIC_SIGNATURES: list[tuple[bytes, str]] = [
+    (b"%PDF", "application/pdf"),
+    (b"PK\x03\x04", "application/zip"),
+    (b"\xd0\xcf\x11\xe0", "application/x-ole-storage"),
+    (b"\x89PNG", "image/png"),
+    (b"\xff\xd8\xff", "image/jpeg"),
+    (b"GIF87a", "image/gif"),
+    (b"GIF89a", "image/gif"),
+    (b"MZ", "application/x-msdownload"),
+    (b"\x7fELF", "application/x-executable"),
+]
+
+# Detected types that are never accepted, whatever the file claims to be.
+EXECUTABLE_TYPES = {"application/x-msdownload", "application/x-executable"}
+
+# Which detected types are plausible for a claimed content type. Claimed
+# types missing here (and not text/*) only accept an exact match.
+COMPATIBLE_TYPES: dict[str, set[str]] = {
+    "application/pdf": {"application/pdf"},
+    "application/zip": {"application/zip"},
+    "application/vnd.openxmlformats-officedocument.wordprocessingml.document": {
+        "application/zip"
+    },
+    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": {
+        "application/zip"
+    },
+    "application/vnd.openxmlformats-officedocument.presentationml.presentation": {
+        "application/zip"
+    },
+    "application/msword": {"application/x-ole-storage"},
+    "application/vnd.ms-excel": {"application/x-ole-storage", "text/plain"},
+    "application/octet-stream": set(),  # no claim to contradict
+}
+
+
+def sniff_content_type(content: bytes) -> str:
+    """Detect the actual content type from magic bytes, falling back to
+    text/plain for anything that decodes cleanly as UTF-8."""
+    for prefix, content_type in MAGIC_SIGNATURES:
+        if content.startswith(prefix):
+            return content_type
+    try:
+        content[:4096].decode("utf-8")
+    except UnicodeDecodeError:
+        return "application/octet-stream"
+    return "text/plain"
+
+
+def content_type_mismatch(claimed: str, detected: str) -> bool:
+    """Whether the detected type contradicts the claimed one. Executables
+    always mismatch; unknown claims are only held to detected binaries."""
+    if detected in EXECUTABLE_TYPES:
+        return True
+    claimed = claimed.split(";")[0].strip().lower()
+    if claimed in COMPATIBLE_TYPES:
+        compatible = COMPATIBLE_TYPES[claimed]
+        return bool(compatible) and detected not in compatible
+    if claimed.startswith("text/") or claimed.endswith(
+        ("/json", "+json", "/xml", "+xml", "/csv")
+    ):
+        return detected != "text/plain"
+    if claimed.startswith("image/"):
+        return detected != claimed
+    return False
+
+
+def _extract_pdf_pages(content: bytes) -> list[str]:
+    from pypdf import PdfReader
+
+    reader = PdfReader(io.BytesIO(content))
+    return [page.extract_text() or "" for page in reader.pages]
+
+
+def _extract_text_pages(content: bytes) -> list[str]:
+    text = content.decode("utf-8", errors="replace")
+    if "\f" in text:
+        return text.split("\f")
+    return [
+        text[i : i + TEXT_PAGE_CHARS] for i in range(0, len(text), TEXT_PAGE_CHARS)
+    ] or [""]
+
+
+def extract_pages(content_type: str, content: bytes) -> list[str]:
+    if content_type == "application/pdf":
+        return _extract_pdf_pages(content)
+    return _extract_text_pages(content)
+
+
+# Keyword heuristics checked in order; the first category with enough
+# signal wins. Filename matches count double since titles are curated.
+CLASSIFICATION_KEYWORDS: list[tuple[FileCategory, list[str]]] = [
+    (FileCategory.CAP_TABLE, ["cap table", "capitalization", "fully diluted", "option pool"]),
+    (
+        FileCategory.FINANCIAL_MODEL,
+        ["p&l", "revenue forecast", "cash flow", "burn rate", "arr", "mrr", "cogs"],
+    ),
+    (
+        FileCategory.LEGAL_DOC,
+        ["agreement", "hereinafter", "indemnif", "warranties", "jurisdiction", "term sheet"],
+    ),
+    (
+        FileCategory.PITCH_DECK,
+        ["pitch", "deck", "problem", "solution", "traction", "market size", "our team"],
+    ),
+]
+
+
+def classify_upload(filename: str, pages: list[str]) -> FileCategory:
+    """Pick a category from filename and extracted-text keyword signals."""
+    name = filename.lower()
+    # Only the first pages are sampled; decks and models show their hand early.
+    text = " ".join(pages[:5]).lower()
+    best_category = FileCategory.OTHER
+    best_score = 0
+    for category, keywords in CLASSIFICATION_KEYWORDS:
+        score = sum(
+            (2 if keyword in name else 0) + (1 if keyword in text else 0)
+            for keyword in keywords
+        )
+        if score > best_score:
+            best_category = category
+            best_score = score
+    return best_category if best_score >= 2 else FileCategory.OTHER
+
+
+def _publish_processed(session: Session, upload: UploadedFile) -> None:
+    owner = session.get(User, upload.owner_id)
+    topic = workspace_topic(
+        organization_id=owner.organization_id if owner else None,
+        user_id=upload.owner_id,
+    )
+    broker.publish(
+        topic,
+        {
+            "type": "upload.processed",
+            "data": {"id": str(upload.id), "status": upload.status},
+        },
+    )
+
+
+async def process_upload(upload_id: uuid.UUID) -> None:
+    """Extract page text from an upload so citations can reference it."""
+    with Session(engine) as session:
+        upload = session.get(UploadedFile, upload_id)
+        if not upload:
+            logger.warning("Upload %s disappeared before processing", upload_id)
+            return
+        upload.status = UploadStatus.PROCESSING
+        session.add(upload)
+        session.commit()
+        try:
+            content = storage.read_file(upload.storage_path)
+            signature = antivirus.scan_bytes(content)
+            if signature:
+                # Quarantine: no pages extracted, no download links served
+                upload.status = UploadStatus.INFECTED
+                upload.error = f"Virus detected: {signature}"
+            else:
+                # Parse by what the file actually is, not what it claims
+                pages = extract_pages(
+                    upload.detected_content_type or upload.content_type, content
+                )
+                for number, text in enumerate(pages, start=1):
+                    session.add(
+                        UploadPage(upload_id=upload.id, number=number, text=text)
+                    )
+                upload.page_count = len(pages)
+                upload.category = classify_upload(upload.filename, pages)
+                upload.status = UploadStatus.PROCESSED
+        except Exception as e:
+            logger.exception("Upload %s processing failed", upload_id)
+            error_reporting.capture_exception(e, upload_id=upload_id)
+            upload.status = UploadStatus.FAILED
+            upload.error = str(e)
+        session.add(upload)
+        session.commit()
+        session.refresh(upload)
+        _publish_processed(session, upload)
+        await publish_domain_event(
+            "upload.processed",
+            {"upload_id": str(upload.id), "status": upload.status},
+        )
+        owner = session.get(User, upload.owner_id)
+        await webhooks.dispatch_event(
+            session,
+            owner.organization_id if owner else None,
+            "upload.processed",
+            {
+                "upload_id": str(upload.id),
+                "filename": upload.filename,
+                "status": upload.status,
+                "category": upload.category,
+                "page_count": upload.page_count,
+            },
+        )
@@ -0,0 +1,203 @@
+"""Valuation estimation from comparable revenue multiples.
+
+Suggests a valuation range by applying industry-typical revenue
+multiples, adjusted for funding stage, to the revenue extracted from the
+company's financials. The assumptions travel with the result so partners
+can see — and challenge — what the range is anchored on.
+"""
+
+import math
+import random
+from typing import Any
+
+from app.models import Cashflow, Company, CompanyStage, MonteCarloRequest
+
+# Typical forward revenue multiples (low, high) by industry bucket.
+# Deliberately coarse; comparables data would refine these.
+REVENUE_MULTIPLES: dict[str, tuple[float, float]] = {
+    "software": (6.0, 12.0),
+    "fintech": (5.0, 10.0),
+    "healthcare": (4.0, 8.0),
+    "consumer": (2.0, 5.0),
+    "hardware": (1.5, 3.5),
+    "default": (3.0, 8.0),
+}
+
+# Earlier stages price more on growth expectations than current revenue.
+STAGE_ADJUSTMENT: dict[CompanyStage, float] = {
+    CompanyStage.PRE_SEED: 1.25,
+    CompanyStage.SEED: 1.15,
+    CompanyStage.SERIES_A: 1.0,
+    CompanyStage.SERIES_B: 0.9,
+    CompanyStage.SERIES_C_PLUS: 0.85,
+    CompanyStage.GROWTH: 0.85,
+}
+
+
+def _industry_bucket(industry: str | None) -> str:
+    lowered = (industry or "").lower()
+    for bucket in ("software", "fintech", "healthcare", "consumer", "hardware"):
+        if bucket in lowered:
+            return bucket
+    if "saas" in lowered or "b2b" in lowered:
+        return "software"
+    return "default"
+
+
+# Forward scenarios over a two-year horizon; multipliers are annual.
+SCENARIO_ASSUMPTIONS: list[tuple[str, float, float]] = [
+    ("bull", 2.5, 1.3),
+    ("base", 1.8, 1.15),
+    ("bear", 1.1, 1.0),
+]
+
+SCENARIO_HORIZON_MONTHS = 24
+
+
+def build_scenarios(figures: dict[str, Any]) -> list[dict[str, Any]]:
+    """
+    Project bull/base/bear outcomes from the extracted financials. Each
+    scenario carries its assumption set so the report can show what the
+    spread rests on. Returns an empty list when there is nothing to
+    project from.
+    """
+    arr = figures.get("arr_usd")
+    burn = figures.get("monthly_burn_usd")
+    cash = figures.get("cash_on_hand_usd")
+    if not arr and not burn:
+        return []
+    years = SCENARIO_HORIZON_MONTHS / 12
+    scenarios = []
+    for name, arr_growth, burn_growth in SCENARIO_ASSUMPTIONS:
+        outcomes: dict[str, Any] = {}
+        if arr:
+            outcomes["projected_arr_usd"] = round(arr * arr_growth**years)
+        if burn:
+            projected_burn = burn * burn_growth**years
+            outcomes["projected_monthly_burn_usd"] = round(projected_burn)
+            if cash:
+                # Approximate the ramp with the average of today's and the
+                # projected burn.
+                average_burn = (burn + projected_burn) / 2
+                outcomes["runway_months"] = round(cash / average_burn, 1)
+        scenarios.append(
+            {
+                "name": name,
+                "assumptions": {
+                    "annual_arr_growth_x": arr_growth,
+                    "annual_burn_growth_x": burn_growth,
+                    "horizon_months": SCENARIO_HORIZON_MONTHS,
+                },
+                "outcomes": outcomes,
+            }
+        )
+    return scenarios
+
+
+def estimate_valuation(company: Company, arr_usd: float) -> dict[str, Any]:
+    """
+    Suggest a valuation range for a company from its annual revenue,
+    returning the multiples and adjustments used as assumptions.
+    """
+    bucket = _industry_bucket(company.industry)
+    low_multiple, high_multiple = REVENUE_MULTIPLES[bucket]
+    adjustment = (
+        STAGE_ADJUSTMENT.get(company.stage, 1.0) if company.stage else 1.0
+    )
+    return {
+        "low_usd": round(arr_usd * low_multiple * adjustment),
+        "high_usd": round(arr_usd * high_multiple * adjustment),
+        "assumptions": {
+            "basis": "revenue_multiple",
+            "arr_usd": arr_usd,
+            "industry_bucket": bucket,
+            "revenue_multiple_range": [low_multiple, high_multiple],
+            "stage_adjustment": adjustment,
+        },
+    }
+
+
+def run_monte_carlo(params: MonteCarloRequest) -> dict[str, Any]:
+    """
+    Simulate the return multiple on a proposed check. Each iteration
+    either writes the company off (with ``failure_probability``) or
+    samples an exit value log-uniformly between the bounds and a dilution
+    uniformly between its bounds.
+    """
+    entry_ownership = params.check_size_usd / params.entry_valuation_usd
+    log_low = math.log(params.exit_valuation_low_usd)
+    log_high = math.log(
+        max(params.exit_valuation_high_usd, params.exit_valuation_low_usd)
+    )
+    multiples = []
+    for _ in range(params.iterations):
+        if random.random() < params.failure_probability:
+            multiples.append(0.0)
+            continue
+        exit_value = math.exp(random.uniform(log_low, log_high))
+        dilution = random.uniform(params.dilution_low, params.dilution_high)
+        proceeds = entry_ownership * (1 - dilution) * exit_value
+        multiples.append(proceeds / params.check_size_usd)
+    multiples.sort()
+
+    def percentile(p: float) -> float:
+        index = min(int(p * len(multiples)), len(multiples) - 1)
+        return round(multiples[index], 2)
+
+    return {
+        "iterations": params.iterations,
+        "mean_multiple": round(sum(multiples) / len(multiples), 2),
+        "percentiles": {
+            "p10": percentile(0.10),
+            "p25": percentile(0.25),
+            "p50": percentile(0.50),
+            "p75": percentile(0.75),
+            "p90": percentile(0.90),
+        },
+        "probability_of_loss": round(
+            sum(1 for multiple in multiples if multiple < 1) / len(multiples), 3
+        ),
+        "probability_3x": round(
+            sum(1 for multiple in multiples if multiple >= 3) / len(multiples), 3
+        ),
+    }
+
+
+def compute_returns(cashflows: list[Cashflow]) -> dict[str, Any]:
+    """
+    Compute MOIC and annualized IRR (XIRR-style, actual/365) from a dated
+    cashflow series. IRR is None when the series never changes sign.
+    Raises ValueError when nothing was invested.
+    """
+    invested = sum(-flow.amount_usd for flow in cashflows if flow.amount_usd < 0)
+    returned = sum(flow.amount_usd for flow in cashflows if flow.amount_usd > 0)
+    if invested <= 0:
+        raise ValueError("Cashflows must include at least one investment")
+    moic = round(returned / invested, 2)
+    irr = None
+    if returned > 0:
+        start = min(flow.date for flow in cashflows)
+        times = [(flow.date - start).days / 365 for flow in cashflows]
+
+        def npv(rate: float) -> float:
+            return sum(
+                flow.amount_usd / (1 + rate) ** t
+                for flow, t in zip(cashflows, times, strict=True)
+            )
+
+        # NPV is monotonically decreasing in the rate, so bisect.
+        low, high = -0.999, 100.0
+        if npv(low) > 0 and npv(high) < 0:
+            for _ in range(100):
+                mid = (low + high) / 2
+                if npv(mid) > 0:
+                    low = mid
+                else:
+                    high = mid
+            irr = round((low + high) / 2, 4)
+    return {
+        "irr": irr,
+        "moic": moic,
+        "total_invested_usd": invested,
+        "total_returned_usd": returned,
+    }
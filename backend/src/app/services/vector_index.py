@@ -0,0 +1,77 @@
+"""Vector index maintenance jobs.
+
+There is no persistent vector store yet — embeddings are computed on
+demand rather than stored — so rebuild, compact, and migrate jobs have
+no index to operate on. Instead of pretending to work, a job validates
+that the embeddings provider for its scope resolves and then finishes as
+SKIPPED, which the job API surfaces to admins. The job plumbing here
+(scoping, progress events, status polling) is what real index
+maintenance will run on once document chunks are persisted.
+"""
+
+import logging
+import uuid
+
+from sqlmodel import Session
+
+from app.core.db import engine
+from app.models import (
+    Organization,
+    VectorIndexJob,
+    VectorIndexJobStatus,
+    VectorIndexOperation,
+    utcnow,
+)
+from app.services import embeddings
+from app.services.events import broker
+
+logger = logging.getLogger(__name__)
+
+
+def _publish_progress(job: VectorIndexJob) -> None:
+    broker.publish(
+        f"indexjob:{job.id}",
+        {
+            "id": str(job.id),
+            "operation": job.operation,
+            "status": job.status,
+            "progress": job.progress,
+        },
+    )
+
+
+async def run_index_job(job_id: uuid.UUID) -> None:
+    """Run a vector index maintenance job, tracking progress as it goes."""
+    with Session(engine) as session:
+        job = session.get(VectorIndexJob, job_id)
+        if not job:
+            logger.warning("Index job %s disappeared before it could run", job_id)
+            return
+        job.status = VectorIndexJobStatus.RUNNING
+        session.add(job)
+        session.commit()
+        session.refresh(job)
+        _publish_progress(job)
+        try:
+            if job.operation in (
+                VectorIndexOperation.REBUILD,
+                VectorIndexOperation.MIGRATE,
+            ):
+                organization = (
+                    session.get(Organization, job.organization_id)
+                    if job.organization_id
+                    else None
+                )
+                # A misconfigured provider should fail the job now, not
+                # the first search after the store ships.
+                embeddings.get_provider(organization)
+            job.status = VectorIndexJobStatus.SKIPPED
+        except Exception as e:
+            logger.exception("Index job %s failed", job_id)
+            job.status = VectorIndexJobStatus.FAILED
+            job.error = str(e)
+        job.completed_at = utcnow()
+        session.add(job)
+        session.commit()
+        session.refresh(job)
+        _publish_progress(job)
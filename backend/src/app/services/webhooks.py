@@ -0,0 +1,155 @@
+import asyncio
+import hashlib
+import hmac
+import json
+import logging
+import uuid
+from datetime import timedelta
+from typing import Any
+
+import httpx
+from sqlmodel import Session, select
+
+from app.core.db import engine
+from app.models import (
+    Webhook,
+    WebhookDelivery,
+    WebhookDeliveryStatus,
+    utcnow,
+)
+from app.services import error_reporting
+
+logger = logging.getLogger(__name__)
+
+DELIVERY_TIMEOUT_SECONDS = 10
+
+SIGNATURE_HEADER = "X-Liora-Signature"
+TIMESTAMP_HEADER = "X-Liora-Timestamp"
+REPLAY_HEADER = "X-Liora-Replay"
+
+# Exponential backoff: 1m, 2m, 4m, ... capped at RETRY_BACKOFF_MAX_SECONDS.
+RETRY_BACKOFF_BASE_SECONDS = 60
+RETRY_BACKOFF_MAX_SECONDS = 6 * 60 * 60
+MAX_DELIVERY_ATTEMPTS = 10
+
+RETRY_POLL_SECONDS = 30
+
+
+def sign_payload(secret: str, timestamp: str, body: bytes) -> str:
+    """HMAC-SHA256 over ``{timestamp}.{body}`` so receivers can reject replays."""
+    message = timestamp.encode() + b"." + body
+    return hmac.new(secret.encode(), message, hashlib.sha256).hexdigest()
+
+
+def enqueue_event(
+    session: Session,
+    organization_id: uuid.UUID | None,
+    event_type: str,
+    data: dict[str, Any],
+) -> list[WebhookDelivery]:
+    """Persist one outbox row per subscribed webhook."""
+    if organization_id is None:
+        return []
+    payload = {
+        "id": str(uuid.uuid4()),
+        "event": event_type,
+        "created_at": utcnow().isoformat(),
+        "data": data,
+    }
+    statement = select(Webhook).where(
+        Webhook.organization_id == organization_id,
+        Webhook.is_active == True,  # noqa: E712
+    )
+    deliveries = []
+    for webhook in session.exec(statement).all():
+        if event_type in webhook.events:
+            delivery = WebhookDelivery(
+                webhook_id=webhook.id, event_type=event_type, payload=payload
+            )
+            session.add(delivery)
+            deliveries.append(delivery)
+    session.commit()
+    for delivery in deliveries:
+        session.refresh(delivery)
+    return deliveries
+
+
+async def attempt_delivery(session: Session, delivery: WebhookDelivery) -> None:
+    """Make one delivery attempt and schedule the next one on failure."""
+    webhook = session.get(Webhook, delivery.webhook_id)
+    if not webhook or not webhook.is_active:
+        delivery.status = WebhookDeliveryStatus.FAILED
+        delivery.last_error = "Webhook deleted or disabled"
+        session.add(delivery)
+        session.commit()
+        return
+    body = json.dumps(delivery.payload).encode()
+    timestamp = str(int(utcnow().timestamp()))
+    headers = {
+        "Content-Type": "application/json",
+        TIMESTAMP_HEADER: timestamp,
+        SIGNATURE_HEADER: sign_payload(webhook.secret, timestamp, body),
+    }
+    if delivery.replay_of is not None:
+        headers[REPLAY_HEADER] = "true"
+    delivery.attempts += 1
+    try:
+        async with httpx.AsyncClient(timeout=DELIVERY_TIMEOUT_SECONDS) as client:
+            response = await client.post(webhook.url, content=body, headers=headers)
+        delivery.response_status = response.status_code
+        response.raise_for_status()
+        delivery.status = WebhookDeliveryStatus.DELIVERED
+        delivery.delivered_at = utcnow()
+        delivery.last_error = None
+    except httpx.HTTPError as e:
+        delivery.last_error = str(e)
+        if delivery.attempts >= MAX_DELIVERY_ATTEMPTS:
+            delivery.status = WebhookDeliveryStatus.FAILED
+            logger.warning(
+                "Webhook %s delivery %s failed permanently after %d attempts",
+                webhook.id,
+                delivery.id,
+                delivery.attempts,
+            )
+        else:
+            backoff = min(
+                RETRY_BACKOFF_BASE_SECONDS * 2 ** (delivery.attempts - 1),
+                RETRY_BACKOFF_MAX_SECONDS,
+            )
+            delivery.next_attempt_at = utcnow() + timedelta(seconds=backoff)
+    session.add(delivery)
+    session.commit()
+
+
+async def dispatch_event(
+    session: Session,
+    organization_id: uuid.UUID | None,
+    event_type: str,
+    data: dict[str, Any],
+) -> None:
+    """Enqueue an event and attempt immediate delivery of each outbox row."""
+    for delivery in enqueue_event(session, organization_id, event_type, data):
+        await attempt_delivery(session, delivery)
+
+
+async def process_due_deliveries() -> None:
+    """Retry pending deliveries whose backoff has elapsed."""
+    with Session(engine) as session:
+        statement = select(WebhookDelivery).where(
+            WebhookDelivery.status == WebhookDeliveryStatus.PENDING,
+            WebhookDelivery.attempts > 0,
+            WebhookDelivery.next_attempt_at <= utcnow(),
+        )
+        for delivery in session.exec(statement).all():
+            await attempt_delivery(session, delivery)
+
+
+async def retry_loop() -> None:
+    """Background loop driving outbox retries; started from the app lifespan."""
+    while True:
+        try:
+            await process_due_deliveries()
+        except Exception as e:
+            logger.exception("Webhook retry loop iteration failed")
+            error_reporting.capture_exception(e, loop="webhook_retry")
+        await asyncio.sleep(RETRY_POLL_SECONDS)
@@ -0,0 +1,106 @@
+import json
+import uuid
+
+from fastapi.testclient import TestClient
+from sqlmodel import Session
+
+from app.core.config import settings
+from app.models import Analysis, AnalysisStatus
+from app.tests.utils.company import create_random_company
+
+
+def test_create_analysis(
+    client: TestClient, superuser_token_headers: dict[str, str], db: Session
+) -> None:
+    company = create_random_company(db)
+    data = {"company_id": str(company.id)}
+    response = client.post(
+        f"{settings.API_V1_STR}/analysis/",
+        headers=superuser_token_headers,
+        json=data,
+    )
+    assert response.status_code == 200
+    content = response.json()
+    assert content["company_id"] == str(company.id)
+    assert "id" in content
+    assert "status" in content
+
+
+def test_create_analysis_company_not_found(
+    client: TestClient, superuser_token_headers: dict[str, str]
+) -> None:
+    data = {"company_id": str(uuid.uuid4())}
+    response = client.post(
+        f"{settings.API_V1_STR}/analysis/",
+        headers=superuser_token_headers,
+        json=data,
+    )
+    assert response.status_code == 404
+    content = response.json()
+    assert content["detail"] == "Company not found"
+
+
+def test_read_analysis_status(
+    client: TestClient, superuser_token_headers: dict[str, str], db: Session
+) -> None:
+    company = create_random_company(db)
+    response = client.post(
+        f"{settings.API_V1_STR}/analysis/",
+        headers=superuser_token_headers,
+        json={"company_id": str(company.id)},
+    )
+    analysis_id = response.json()["id"]
+    response = client.get(
+        f"{settings.API_V1_STR}/analysis/{analysis_id}/status",
+        headers=superuser_token_headers,
+    )
+    assert response.status_code == 200
+    content = response.json()
+    assert content["id"] == analysis_id
+    assert content["status"] in ("pending", "running", "completed", "failed")
+
+
+def test_read_analysis_not_found(
+    client: TestClient, superuser_token_headers: dict[str, str]
+) -> None:
+    response = client.get(
+        f"{settings.API_V1_STR}/analysis/{uuid.uuid4()}",
+        headers=superuser_token_headers,
+    )
+    assert response.status_code == 404
+    content = response.json()
+    assert content["detail"] == "Analysis not found"
+
+
+def test_stream_analysis_events(
+    client: TestClient, superuser_token_headers: dict[str, str], db: Session
+) -> None:
+    company = create_random_company(db)
+    analysis = Analysis(
+        company_id=company.id,
+        requested_by=company.owner_id,
+        status=AnalysisStatus.COMPLETED,
+        progress=100,
+    )
+    db.add(analysis)
+    db.commit()
+    db.refresh(analysis)
+    # A terminal analysis yields its snapshot event and closes the stream,
+    # so reading the whole response proves both arrival and termination.
+    response = client.get(
+        f"{settings.API_V1_STR}/analysis/{analysis.id}/events",
+        headers=superuser_token_headers,
+    )
+    assert response.status_code == 200
+    assert response.headers["content-type"].startswith("text/event-stream")
+    assert "event: status" in response.text
+    events = [
+        json.loads(line.removeprefix("data: "))
+        for line in response.text.splitlines()
+        if line.startswith("data: ")
+    ]
+    assert events
+    snapshot = events[0]
+    assert snapshot["id"] == str(analysis.id)
+    assert snapshot["status"] == "completed"
+    assert snapshot["progress"] == 100
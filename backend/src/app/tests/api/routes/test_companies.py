@@ -0,0 +1,161 @@
+import uuid
+
+from fastapi.testclient import TestClient
+from sqlmodel import Session
+
+from app.core.config import settings
+from app.tests.utils.company import create_random_company
+
+
+def _current_etag(
+    client: TestClient, headers: dict[str, str], company_id: uuid.UUID
+) -> str:
+    response = client.get(
+        f"{settings.API_V1_STR}/companies/{company_id}", headers=headers
+    )
+    assert response.status_code == 200
+    return response.headers["ETag"]
+
+
+def test_read_company_returns_etag(
+    client: TestClient, superuser_token_headers: dict[str, str], db: Session
+) -> None:
+    company = create_random_company(db)
+    response = client.get(
+        f"{settings.API_V1_STR}/companies/{company.id}",
+        headers=superuser_token_headers,
+    )
+    assert response.status_code == 200
+    etag = response.headers["ETag"]
+    assert etag.startswith('"') and etag.endswith('"')
+
+
+def test_if_none_match_returns_304(
+    client: TestClient, superuser_token_headers: dict[str, str], db: Session
+) -> None:
+    company = create_random_company(db)
+    etag = _current_etag(client, superuser_token_headers, company.id)
+    response = client.get(
+        f"{settings.API_V1_STR}/companies/{company.id}",
+        headers={**superuser_token_headers, "If-None-Match": etag},
+    )
+    assert response.status_code == 304
+    assert response.headers["ETag"] == etag
+    assert not response.content
+
+
+def test_update_without_if_match_rejected(
+    client: TestClient, superuser_token_headers: dict[str, str], db: Session
+) -> None:
+    company = create_random_company(db)
+    response = client.patch(
+        f"{settings.API_V1_STR}/companies/{company.id}",
+        headers=superuser_token_headers,
+        json={"industry": "fintech"},
+    )
+    assert response.status_code == 428
+    assert response.json()["detail"] == "If-Match header required"
+
+
+def test_update_with_stale_etag_rejected(
+    client: TestClient, superuser_token_headers: dict[str, str], db: Session
+) -> None:
+    company = create_random_company(db)
+    etag = _current_etag(client, superuser_token_headers, company.id)
+    headers = {**superuser_token_headers, "If-Match": etag}
+    response = client.patch(
+        f"{settings.API_V1_STR}/companies/{company.id}",
+        headers=headers,
+        json={"industry": "fintech"},
+    )
+    assert response.status_code == 200
+    # The first update changed the resource, so the old ETag is now stale
+    response = client.patch(
+        f"{settings.API_V1_STR}/companies/{company.id}",
+        headers=headers,
+        json={"industry": "healthcare"},
+    )
+    assert response.status_code == 412
+    assert response.json()["detail"] == "Resource was modified by someone else"
+
+
+def test_update_with_current_etag_succeeds(
+    client: TestClient, superuser_token_headers: dict[str, str], db: Session
+) -> None:
+    company = create_random_company(db)
+    etag = _current_etag(client, superuser_token_headers, company.id)
+    response = client.patch(
+        f"{settings.API_V1_STR}/companies/{company.id}",
+        headers={**superuser_token_headers, "If-Match": etag},
+        json={"industry": "fintech"},
+    )
+    assert response.status_code == 200
+    assert response.json()["industry"] == "fintech"
+    assert _current_etag(client, superuser_token_headers, company.id) != etag
+
+
+def test_merge_patch_leaves_absent_fields_untouched(
+    client: TestClient, superuser_token_headers: dict[str, str], db: Session
+) -> None:
+    company = create_random_company(db)
+    company.website = "https://example.com"
+    db.add(company)
+    db.commit()
+    etag = _current_etag(client, superuser_token_headers, company.id)
+    response = client.patch(
+        f"{settings.API_V1_STR}/companies/{company.id}",
+        headers={**superuser_token_headers, "If-Match": etag},
+        json={"industry": "fintech"},
+    )
+    assert response.status_code == 200
+    content = response.json()
+    assert content["industry"] == "fintech"
+    assert content["website"] == "https://example.com"
+    assert content["name"] == company.name
+
+
+def test_merge_patch_null_clears_field(
+    client: TestClient, superuser_token_headers: dict[str, str], db: Session
+) -> None:
+    company = create_random_company(db)
+    company.website = "https://example.com"
+    db.add(company)
+    db.commit()
+    etag = _current_etag(client, superuser_token_headers, company.id)
+    response = client.patch(
+        f"{settings.API_V1_STR}/companies/{company.id}",
+        headers={**superuser_token_headers, "If-Match": etag},
+        json={"website": None},
+    )
+    assert response.status_code == 200
+    content = response.json()
+    assert content["website"] is None
+    assert content["industry"] == company.industry
+
+
+def test_merge_patch_cannot_clear_name(
+    client: TestClient, superuser_token_headers: dict[str, str], db: Session
+) -> None:
+    company = create_random_company(db)
+    etag = _current_etag(client, superuser_token_headers, company.id)
+    response = client.patch(
+        f"{settings.API_V1_STR}/companies/{company.id}",
+        headers={**superuser_token_headers, "If-Match": etag},
+        json={"name": None},
+    )
+    assert response.status_code == 422
+    assert response.json()["detail"] == "name cannot be cleared"
+
+
+def test_merge_patch_rejects_unknown_fields(
+    client: TestClient, superuser_token_headers: dict[str, str], db: Session
+) -> None:
+    company = create_random_company(db)
+    etag = _current_etag(client, superuser_token_headers, company.id)
+    response = client.patch(
+        f"{settings.API_V1_STR}/companies/{company.id}",
+        headers={**superuser_token_headers, "If-Match": etag},
+        json={"valuation": 100},
+    )
+    assert response.status_code == 422
+    assert response.json()["detail"] == "Unknown fields: valuation"
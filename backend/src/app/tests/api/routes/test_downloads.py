@@ -0,0 +1,68 @@
+import uuid
+
+from fastapi.testclient import TestClient
+
+from app.core.config import settings
+from app.services.downloads import generate_download_token
+
+
+def test_download_with_garbage_token(client: TestClient) -> None:
+    response = client.get(f"{settings.API_V1_STR}/downloads/not-a-token")
+    assert response.status_code == 403
+    assert response.json()["detail"] == "Invalid or expired download link"
+
+
+def test_upload_link_round_trip(
+    client: TestClient, superuser_token_headers: dict[str, str]
+) -> None:
+    content = b"quarterly revenue figures"
+    response = client.post(
+        f"{settings.API_V1_STR}/uploads/",
+        headers=superuser_token_headers,
+        files={"file": ("notes.txt", content, "text/plain")},
+    )
+    assert response.status_code == 200
+    upload_id = response.json()["id"]
+    response = client.post(
+        f"{settings.API_V1_STR}/uploads/{upload_id}/link",
+        headers=superuser_token_headers,
+    )
+    assert response.status_code == 200
+    link = response.json()
+    assert "expires_at" in link
+    token = link["url"].rsplit("/", 1)[-1]
+    # The link itself is unauthenticated; the signature gates access
+    response = client.get(f"{settings.API_V1_STR}/downloads/{token}")
+    assert response.status_code == 200
+    assert response.content == content
+
+
+def test_tampered_link_rejected(
+    client: TestClient, superuser_token_headers: dict[str, str]
+) -> None:
+    content = b"term sheet draft"
+    response = client.post(
+        f"{settings.API_V1_STR}/uploads/",
+        headers=superuser_token_headers,
+        files={"file": ("term-sheet.txt", content, "text/plain")},
+    )
+    upload_id = response.json()["id"]
+    response = client.post(
+        f"{settings.API_V1_STR}/uploads/{upload_id}/link",
+        headers=superuser_token_headers,
+    )
+    token = response.json()["url"].rsplit("/", 1)[-1]
+    header, payload, signature = token.split(".")
+    flipped = ("A" if signature[0] != "A" else "B") + signature[1:]
+    response = client.get(
+        f"{settings.API_V1_STR}/downloads/{header}.{payload}.{flipped}"
+    )
+    assert response.status_code == 403
+    assert response.json()["detail"] == "Invalid or expired download link"
+
+
+def test_valid_token_for_missing_upload(client: TestClient) -> None:
+    token, _ = generate_download_token("file", uuid.uuid4())
+    response = client.get(f"{settings.API_V1_STR}/downloads/{token}")
+    assert response.status_code == 404
+    assert response.json()["detail"] == "Upload not found"
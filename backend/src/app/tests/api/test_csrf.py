@@ -0,0 +1,69 @@
+from fastapi.testclient import TestClient
+
+from app.api import csrf
+from app.core.config import settings
+from app.tests.utils.utils import random_lower_string
+
+
+def test_issue_csrf_token(client: TestClient) -> None:
+    response = client.get(f"{settings.API_V1_STR}/utils/csrf-token/")
+    assert response.status_code == 200
+    token = response.json()["message"]
+    assert response.cookies.get(csrf.CSRF_COOKIE) == token
+    client.cookies.clear()
+
+
+def test_cookie_request_without_token_rejected(client: TestClient) -> None:
+    response = client.post(
+        f"{settings.API_V1_STR}/login/access-token",
+        data={"username": "nobody@example.com", "password": "wrong"},
+        cookies={"session": "opaque"},
+    )
+    assert response.status_code == 403
+    assert response.json()["detail"] == "CSRF token missing or invalid"
+
+
+def test_cookie_request_with_mismatched_token_rejected(client: TestClient) -> None:
+    response = client.post(
+        f"{settings.API_V1_STR}/login/access-token",
+        data={"username": "nobody@example.com", "password": "wrong"},
+        cookies={csrf.CSRF_COOKIE: csrf.new_token()},
+        headers={csrf.CSRF_HEADER: csrf.new_token()},
+    )
+    assert response.status_code == 403
+    assert response.json()["detail"] == "CSRF token missing or invalid"
+
+
+def test_cookie_request_with_matching_token_passes(client: TestClient) -> None:
+    token = csrf.new_token()
+    response = client.post(
+        f"{settings.API_V1_STR}/login/access-token",
+        data={"username": "nobody@example.com", "password": "wrong"},
+        cookies={csrf.CSRF_COOKIE: token},
+        headers={csrf.CSRF_HEADER: token},
+    )
+    # Past the CSRF check; the credentials themselves are what fail
+    assert response.status_code == 400
+    assert response.json()["detail"] == "Incorrect email or password"
+
+
+def test_safe_method_needs_no_token(client: TestClient) -> None:
+    response = client.get(
+        f"{settings.API_V1_STR}/utils/health-check/",
+        cookies={"session": "opaque"},
+    )
+    assert response.status_code == 200
+
+
+def test_bearer_request_with_cookies_passes(
+    client: TestClient, superuser_token_headers: dict[str, str]
+) -> None:
+    # A cross-site page cannot attach an Authorization header, so bearer
+    # requests skip the double-submit check even when cookies ride along.
+    response = client.post(
+        f"{settings.API_V1_STR}/companies/",
+        headers=superuser_token_headers,
+        cookies={"tracking": "opaque"},
+        json={"name": random_lower_string(), "industry": "software"},
+    )
+    assert response.status_code == 200
@@ -0,0 +1,67 @@
+import uuid
+
+from fastapi.testclient import TestClient
+from sqlmodel import Session, func, select
+
+from app.core.config import settings
+from app.models import Company
+from app.tests.utils.utils import random_lower_string
+
+
+def test_retry_with_same_key_replays_first_response(
+    client: TestClient, superuser_token_headers: dict[str, str], db: Session
+) -> None:
+    name = random_lower_string()
+    key = str(uuid.uuid4())
+    headers = {**superuser_token_headers, "Idempotency-Key": key}
+    data = {"name": name, "industry": "software"}
+    first = client.post(
+        f"{settings.API_V1_STR}/companies/", headers=headers, json=data
+    )
+    assert first.status_code == 200
+    retry = client.post(
+        f"{settings.API_V1_STR}/companies/", headers=headers, json=data
+    )
+    assert retry.status_code == 200
+    assert retry.json()["id"] == first.json()["id"]
+    assert retry.headers.get("Idempotency-Replayed") == "true"
+    count = db.exec(
+        select(func.count()).select_from(Company).where(Company.name == name)
+    ).one()
+    assert count == 1
+
+
+def test_different_keys_create_different_companies(
+    client: TestClient, superuser_token_headers: dict[str, str]
+) -> None:
+    data = {"name": random_lower_string(), "industry": "software"}
+    first = client.post(
+        f"{settings.API_V1_STR}/companies/",
+        headers={**superuser_token_headers, "Idempotency-Key": str(uuid.uuid4())},
+        json=data,
+    )
+    second = client.post(
+        f"{settings.API_V1_STR}/companies/",
+        headers={**superuser_token_headers, "Idempotency-Key": str(uuid.uuid4())},
+        json={"name": random_lower_string(), "industry": "software"},
+    )
+    assert first.status_code == 200
+    assert second.status_code == 200
+    assert second.json()["id"] != first.json()["id"]
+    assert "Idempotency-Replayed" not in second.headers
+
+
+def test_requests_without_key_are_not_cached(
+    client: TestClient, superuser_token_headers: dict[str, str]
+) -> None:
+    first = client.post(
+        f"{settings.API_V1_STR}/companies/",
+        headers=superuser_token_headers,
+        json={"name": random_lower_string(), "industry": "software"},
+    )
+    second = client.post(
+        f"{settings.API_V1_STR}/companies/",
+        headers=superuser_token_headers,
+        json={"name": random_lower_string(), "industry": "software"},
+    )
+    assert first.json()["id"] != second.json()["id"]
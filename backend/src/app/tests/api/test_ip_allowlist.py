@@ -0,0 +1,104 @@
+from fastapi.testclient import TestClient
+from sqlmodel import Session
+
+from app import crud
+from app.core.config import settings
+from app.models import IpAllowlistConfig, Organization
+from app.services.ip_allowlist import client_allowed
+from app.tests.utils.utils import random_lower_string
+
+
+def test_disabled_config_allows_everything() -> None:
+    config = IpAllowlistConfig()
+    assert client_allowed(config, "203.0.113.7")
+    assert client_allowed(config, None)
+
+
+def test_enabled_config_without_ranges_allows_everything() -> None:
+    config = IpAllowlistConfig(enabled=True, cidrs=[])
+    assert client_allowed(config, "203.0.113.7")
+
+
+def test_address_inside_range_allowed() -> None:
+    config = IpAllowlistConfig(enabled=True, cidrs=["10.0.0.0/8", "192.0.2.0/24"])
+    assert client_allowed(config, "10.1.2.3")
+    assert client_allowed(config, "192.0.2.55")
+
+
+def test_address_outside_range_blocked() -> None:
+    config = IpAllowlistConfig(enabled=True, cidrs=["192.0.2.0/24"])
+    assert not client_allowed(config, "198.51.100.1")
+    assert not client_allowed(config, "not-an-ip")
+    assert not client_allowed(config, None)
+
+
+def test_malformed_range_skipped() -> None:
+    config = IpAllowlistConfig(enabled=True, cidrs=["not-a-range", "192.0.2.0/24"])
+    assert client_allowed(config, "192.0.2.1")
+    assert not client_allowed(config, "198.51.100.1")
+
+
+def _set_allowlist(db: Session, cidrs: list[str], enabled: bool = True) -> None:
+    user = crud.get_user_by_email(session=db, email=settings.EMAIL_TEST_USER)
+    assert user is not None
+    if user.organization_id:
+        organization = db.get(Organization, user.organization_id)
+    else:
+        organization = Organization(name=random_lower_string())
+        db.add(organization)
+        db.commit()
+        db.refresh(organization)
+        user.organization_id = organization.id
+        db.add(user)
+    assert organization is not None
+    organization.settings = {
+        **organization.settings,
+        "ip_allowlist": {"enabled": enabled, "cidrs": cidrs},
+    }
+    db.add(organization)
+    db.commit()
+
+
+def test_bearer_request_from_blocked_address_rejected(
+    client: TestClient, normal_user_token_headers: dict[str, str], db: Session
+) -> None:
+    _set_allowlist(db, ["192.0.2.0/24"])
+    try:
+        response = client.get(
+            f"{settings.API_V1_STR}/users/me", headers=normal_user_token_headers
+        )
+        assert response.status_code == 403
+        assert response.json()["detail"] == "Source IP is not allowlisted"
+    finally:
+        _set_allowlist(db, [], enabled=False)
+
+
+def test_proxy_header_cannot_bypass_allowlist(
+    client: TestClient, normal_user_token_headers: dict[str, str], db: Session
+) -> None:
+    # Attribution uses the socket address, never a spoofable proxy header
+    _set_allowlist(db, ["192.0.2.0/24"])
+    try:
+        response = client.get(
+            f"{settings.API_V1_STR}/users/me",
+            headers={
+                **normal_user_token_headers,
+                "X-Forwarded-For": "192.0.2.10",
+            },
+        )
+        assert response.status_code == 403
+    finally:
+        _set_allowlist(db, [], enabled=False)
+
+
+def test_unauthenticated_requests_pass_through(
+    client: TestClient, db: Session
+) -> None:
+    # Login and docs are not org-scoped; blocking them would lock admins
+    # out of fixing a bad policy.
+    _set_allowlist(db, ["192.0.2.0/24"])
+    try:
+        response = client.get(f"{settings.API_V1_STR}/utils/health-check/")
+        assert response.status_code == 200
+    finally:
+        _set_allowlist(db, [], enabled=False)
@@ -0,0 +1,77 @@
+import uuid
+from datetime import timedelta
+from unittest.mock import patch
+
+import jwt
+from fastapi import Request
+
+from app.api import ratelimit
+from app.core import security
+from app.core.config import settings
+
+
+def _request(
+    headers: dict[str, str] | None = None, host: str = "203.0.113.9"
+) -> Request:
+    scope = {
+        "type": "http",
+        "method": "POST",
+        "path": "/",
+        "headers": [
+            (key.lower().encode(), value.encode())
+            for key, value in (headers or {}).items()
+        ],
+        "query_string": b"",
+        "client": (host, 4242),
+    }
+    return Request(scope)
+
+
+def _forged_token(subject: uuid.UUID) -> str:
+    return jwt.encode(
+        {"sub": str(subject)}, "not-the-server-key", algorithm=security.ALGORITHM
+    )
+
+
+def test_valid_token_keys_on_user() -> None:
+    user_id = uuid.uuid4()
+    token = security.create_access_token(
+        user_id, expires_delta=timedelta(minutes=5)
+    )
+    request = _request({"Authorization": f"Bearer {token}"})
+    assert ratelimit.client_key(request) == f"user:{user_id}"
+
+
+def test_forged_token_falls_back_to_client_ip() -> None:
+    request = _request({"Authorization": f"Bearer {_forged_token(uuid.uuid4())}"})
+    assert ratelimit.client_key(request) == "ip:203.0.113.9"
+
+
+def test_unauthenticated_request_keys_on_client_ip() -> None:
+    assert ratelimit.client_key(_request()) == "ip:203.0.113.9"
+
+
+def test_rotating_forged_tokens_share_one_bucket() -> None:
+    ratelimit._counters.clear()
+    with patch("app.core.config.settings.RATE_LIMIT_PER_MINUTE", 2):
+        for _ in range(2):
+            request = _request(
+                {"Authorization": f"Bearer {_forged_token(uuid.uuid4())}"}
+            )
+            allowed, _headers = ratelimit.check(request)
+            assert allowed
+        request = _request(
+            {"Authorization": f"Bearer {_forged_token(uuid.uuid4())}"}
+        )
+        allowed, headers = ratelimit.check(request)
+    assert not allowed
+    assert headers["X-RateLimit-Remaining"] == "0"
+    assert "Retry-After" in headers
+    ratelimit._counters.clear()
+
+
+def test_limit_disabled_by_default() -> None:
+    assert settings.RATE_LIMIT_PER_MINUTE == 0
+    allowed, headers = ratelimit.check(_request())
+    assert allowed
+    assert headers == {}
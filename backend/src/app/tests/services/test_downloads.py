@@ -0,0 +1,60 @@
+import uuid
+from datetime import datetime, timedelta, timezone
+
+import jwt
+
+from app.core import security
+from app.core.config import settings
+from app.services.downloads import (
+    TOKEN_AUDIENCE,
+    generate_download_token,
+    verify_download_token,
+)
+
+
+def test_token_round_trip() -> None:
+    resource_id = uuid.uuid4()
+    token, expires_at = generate_download_token("file", resource_id)
+    claims = verify_download_token(token)
+    assert claims is not None
+    assert claims["sub"] == str(resource_id)
+    assert claims["kind"] == "file"
+    assert expires_at > datetime.now(timezone.utc)
+
+
+def test_report_token_carries_format_and_lang() -> None:
+    token, _ = generate_download_token(
+        "report", uuid.uuid4(), format="pdf", lang="de"
+    )
+    claims = verify_download_token(token)
+    assert claims is not None
+    assert claims["format"] == "pdf"
+    assert claims["lang"] == "de"
+
+
+def test_expired_token_rejected() -> None:
+    now = datetime.now(timezone.utc)
+    claims = {
+        "exp": now - timedelta(minutes=1),
+        "nbf": now - timedelta(minutes=30),
+        "aud": TOKEN_AUDIENCE,
+        "sub": str(uuid.uuid4()),
+        "kind": "file",
+    }
+    token = jwt.encode(claims, settings.SECRET_KEY, algorithm=security.ALGORITHM)
+    assert verify_download_token(token) is None
+
+
+def test_session_token_rejected_by_audience() -> None:
+    # An access token signed with the same key must not open downloads
+    token = security.create_access_token(
+        uuid.uuid4(), expires_delta=timedelta(minutes=5)
+    )
+    assert verify_download_token(token) is None
+
+
+def test_tampered_token_rejected() -> None:
+    token, _ = generate_download_token("file", uuid.uuid4())
+    header, payload, signature = token.split(".")
+    flipped = ("A" if signature[0] != "A" else "B") + signature[1:]
+    assert verify_download_token(".".join([header, payload, flipped])) is None
@@ -0,0 +1,70 @@
+from pathlib import Path
+
+from sqlmodel import Session
+
+from app.models import Analysis, Company, IdempotencyKey, UploadedFile, UploadPage
+from app.services import storage
+from app.services.privacy import erase_company
+from app.tests.utils.company import create_random_company
+
+
+def test_erase_company_scrubs_everything(db: Session) -> None:
+    company = create_random_company(db)
+    storage_path = storage.save_upload("notes.txt", b"diligence notes")
+    upload = UploadedFile(
+        filename="notes.txt",
+        content_type="text/plain",
+        size=15,
+        storage_path=storage_path,
+        company_id=company.id,
+        owner_id=company.owner_id,
+    )
+    db.add(upload)
+    db.commit()
+    db.refresh(upload)
+    page = UploadPage(upload_id=upload.id, number=1, text="diligence notes")
+    analysis = Analysis(company_id=company.id, requested_by=company.owner_id)
+    cached = IdempotencyKey(
+        key="create-company-retry",
+        user_id=company.owner_id,
+        endpoint="create_company",
+        response_body={"id": str(company.id), "name": company.name},
+    )
+    db.add(page)
+    db.add(analysis)
+    db.add(cached)
+    db.commit()
+
+    report = erase_company(db, company)
+
+    assert report["company_id"] == str(company.id)
+    assert report["erased"]["uploads"] == 1
+    assert report["erased"]["upload_pages"] == 1
+    assert report["erased"]["stored_files"] == 1
+    assert report["erased"]["analyses"] == 1
+    assert report["erased"]["cached_responses"] == 1
+    assert db.get(UploadedFile, upload.id) is None
+    assert db.get(Analysis, analysis.id) is None
+    assert db.get(IdempotencyKey, cached.id) is None
+    assert not Path(storage_path).exists()
+
+
+def test_erase_report_verification_reflects_the_database(db: Session) -> None:
+    company = create_random_company(db)
+    company_id = company.id
+
+    report = erase_company(db, company)
+
+    # Every verification entry is a fresh count against the live tables,
+    # so verified means the data is actually gone, not just scheduled.
+    assert set(report["verification"]) == {
+        "company",
+        "uploads",
+        "analyses",
+        "founders",
+        "funding_rounds",
+        "vector_index_jobs",
+    }
+    assert all(report["verification"].values())
+    assert report["verified"] is True
+    assert db.get(Company, company_id) is None
@@ -0,0 +1,170 @@
+import asyncio
+import hashlib
+import hmac
+import json
+from unittest.mock import patch
+
+import httpx
+from sqlmodel import Session
+
+from app.models import Organization, Webhook, WebhookDeliveryStatus, utcnow
+from app.services import webhooks
+from app.tests.utils.user import create_random_user
+from app.tests.utils.utils import random_lower_string
+
+
+def _create_webhook(
+    db: Session, events: list[str], url: str = "https://receiver.example.com/hook"
+) -> Webhook:
+    organization = Organization(name=random_lower_string())
+    db.add(organization)
+    db.commit()
+    db.refresh(organization)
+    user = create_random_user(db)
+    webhook = Webhook(
+        url=url,
+        events=events,
+        organization_id=organization.id,
+        secret=random_lower_string(),
+        created_by=user.id,
+    )
+    db.add(webhook)
+    db.commit()
+    db.refresh(webhook)
+    return webhook
+
+
+class _CapturingClient:
+    """Stands in for httpx.AsyncClient and records the outbound request."""
+
+    captured: dict = {}
+
+    def __init__(self, timeout: float | None = None) -> None:
+        pass
+
+    async def __aenter__(self) -> "_CapturingClient":
+        return self
+
+    async def __aexit__(self, *args: object) -> None:
+        return None
+
+    async def post(
+        self, url: str, content: bytes, headers: dict[str, str]
+    ) -> httpx.Response:
+        _CapturingClient.captured = {
+            "url": url,
+            "content": content,
+            "headers": headers,
+        }
+        return httpx.Response(200, request=httpx.Request("POST", url))
+
+
+def test_sign_payload_is_deterministic_hmac() -> None:
+    signature = webhooks.sign_payload("s3cret", "1700000000", b'{"a":1}')
+    expected = hmac.new(
+        b"s3cret", b'1700000000.{"a":1}', hashlib.sha256
+    ).hexdigest()
+    assert signature == expected
+    # Any change to secret, timestamp, or body must change the signature
+    assert webhooks.sign_payload("other", "1700000000", b'{"a":1}') != signature
+    assert webhooks.sign_payload("s3cret", "1700000001", b'{"a":1}') != signature
+    assert webhooks.sign_payload("s3cret", "1700000000", b'{"a":2}') != signature
+
+
+def test_enqueue_only_for_subscribed_events(db: Session) -> None:
+    webhook = _create_webhook(db, events=["analysis.completed"])
+    deliveries = webhooks.enqueue_event(
+        db, webhook.organization_id, "upload.processed", {"id": "x"}
+    )
+    assert deliveries == []
+    deliveries = webhooks.enqueue_event(
+        db, webhook.organization_id, "analysis.completed", {"id": "x"}
+    )
+    assert len(deliveries) == 1
+    assert deliveries[0].webhook_id == webhook.id
+    assert deliveries[0].payload["event"] == "analysis.completed"
+    assert deliveries[0].payload["data"] == {"id": "x"}
+
+
+def test_delivery_carries_verifiable_signature(db: Session) -> None:
+    webhook = _create_webhook(db, events=["analysis.completed"])
+    [delivery] = webhooks.enqueue_event(
+        db, webhook.organization_id, "analysis.completed", {"id": "x"}
+    )
+    with patch("app.services.webhooks.httpx.AsyncClient", _CapturingClient):
+        asyncio.run(webhooks.attempt_delivery(db, delivery))
+    captured = _CapturingClient.captured
+    assert captured["url"] == webhook.url
+    assert json.loads(captured["content"]) == delivery.payload
+    timestamp = captured["headers"][webhooks.TIMESTAMP_HEADER]
+    # A receiver holding the shared secret can recompute the signature
+    assert captured["headers"][webhooks.SIGNATURE_HEADER] == webhooks.sign_payload(
+        webhook.secret, timestamp, captured["content"]
+    )
+
+
+class _FailingClient:
+    """Stands in for httpx.AsyncClient and refuses every connection."""
+
+    def __init__(self, timeout: float | None = None) -> None:
+        pass
+
+    async def __aenter__(self) -> "_FailingClient":
+        return self
+
+    async def __aexit__(self, *args: object) -> None:
+        return None
+
+    async def post(
+        self, url: str, content: bytes, headers: dict[str, str]
+    ) -> httpx.Response:
+        raise httpx.ConnectError("connection refused")
+
+
+def test_successful_delivery_marked_delivered(db: Session) -> None:
+    webhook = _create_webhook(db, events=["analysis.completed"])
+    [delivery] = webhooks.enqueue_event(
+        db, webhook.organization_id, "analysis.completed", {"id": "x"}
+    )
+    with patch("app.services.webhooks.httpx.AsyncClient", _CapturingClient):
+        asyncio.run(webhooks.attempt_delivery(db, delivery))
+    assert delivery.status == WebhookDeliveryStatus.DELIVERED
+    assert delivery.attempts == 1
+    assert delivery.response_status == 200
+    assert delivery.delivered_at is not None
+    assert delivery.last_error is None
+
+
+def test_failed_delivery_backs_off_exponentially(db: Session) -> None:
+    webhook = _create_webhook(db, events=["analysis.completed"])
+    [delivery] = webhooks.enqueue_event(
+        db, webhook.organization_id, "analysis.completed", {"id": "x"}
+    )
+    with patch("app.services.webhooks.httpx.AsyncClient", _FailingClient):
+        asyncio.run(webhooks.attempt_delivery(db, delivery))
+        assert delivery.status == WebhookDeliveryStatus.PENDING
+        assert delivery.attempts == 1
+        assert delivery.last_error is not None
+        first_delay = (delivery.next_attempt_at - utcnow()).total_seconds()
+        asyncio.run(webhooks.attempt_delivery(db, delivery))
+    assert delivery.attempts == 2
+    second_delay = (delivery.next_attempt_at - utcnow()).total_seconds()
+    # 1m after the first failure, 2m after the second
+    assert webhooks.RETRY_BACKOFF_BASE_SECONDS - 5 < first_delay
+    assert first_delay <= webhooks.RETRY_BACKOFF_BASE_SECONDS
+    assert 2 * webhooks.RETRY_BACKOFF_BASE_SECONDS - 5 < second_delay
+    assert second_delay <= 2 * webhooks.RETRY_BACKOFF_BASE_SECONDS
+
+
+def test_delivery_fails_permanently_after_max_attempts(db: Session) -> None:
+    webhook = _create_webhook(db, events=["analysis.completed"])
+    [delivery] = webhooks.enqueue_event(
+        db, webhook.organization_id, "analysis.completed", {"id": "x"}
+    )
+    delivery.attempts = webhooks.MAX_DELIVERY_ATTEMPTS - 1
+    db.add(delivery)
+    db.commit()
+    with patch("app.services.webhooks.httpx.AsyncClient", _FailingClient):
+        asyncio.run(webhooks.attempt_delivery(db, delivery))
+    assert delivery.status == WebhookDeliveryStatus.FAILED
+    assert delivery.attempts == webhooks.MAX_DELIVERY_ATTEMPTS
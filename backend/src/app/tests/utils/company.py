@@ -0,0 +1,15 @@
+from sqlmodel import Session
+
+from app import crud
+from app.models import Company, CompanyCreate
+from app.tests.utils.user import create_random_user
+from app.tests.utils.utils import random_lower_string
+
+
+def create_random_company(db: Session) -> Company:
+    user = create_random_user(db)
+    owner_id = user.id
+    assert owner_id is not None
+    name = random_lower_string()
+    company_in = CompanyCreate(name=name, industry="software")
+    return crud.create_company(session=db, company_in=company_in, owner_id=owner_id)
@@ -35,12 +35,14 @@ def send_email(
     email_to: str,
     subject: str = "",
     html_content: str = "",
+    headers: dict[str, str] | None = None,
 ) -> None:
     assert settings.emails_enabled, "no provided configuration for email variables"
     message = emails.Message(
         subject=subject,
         html=html_content,
         mail_from=(settings.EMAILS_FROM_NAME, settings.EMAILS_FROM_EMAIL),
+        headers=headers,
     )
     smtp_options = {"host": settings.SMTP_HOST, "port": settings.SMTP_PORT}
     if settings.SMTP_TLS:
@@ -100,6 +102,68 @@ def generate_new_account_email(
     return EmailData(html_content=html_content, subject=subject)
 
 
+def generate_analysis_complete_email(
+    email_to: str, company_name: str, analysis_id: str, status: str
+) -> EmailData:
+    project_name = settings.PROJECT_NAME
+    subject = f"{project_name} - Analysis of {company_name} {status}"
+    link = f"{settings.FRONTEND_HOST}/analysis/{analysis_id}"
+    html_content = render_email_template(
+        template_name="analysis_complete.html",
+        context={
+            "project_name": settings.PROJECT_NAME,
+            "company_name": company_name,
+            "status": status,
+            "link": link,
+        },
+    )
+    return EmailData(html_content=html_content, subject=subject)
+
+
+def generate_alert_email(
+    email_to: str,
+    rule_name: str,
+    company_name: str,
+    analysis_id: str,
+    score: float | None,
+) -> EmailData:
+    project_name = settings.PROJECT_NAME
+    subject = f"{project_name} - Alert '{rule_name}' triggered by {company_name}"
+    link = f"{settings.FRONTEND_HOST}/analysis/{analysis_id}"
+    html_content = render_email_template(
+        template_name="analysis_complete.html",
+        context={
+            "project_name": settings.PROJECT_NAME,
+            "company_name": company_name,
+            "status": f"scored {score} and triggered alert '{rule_name}'",
+            "link": link,
+        },
+    )
+    return EmailData(html_content=html_content, subject=subject)
+
+
+def generate_weekly_digest_email(
+    email_to: str,
+    organization_name: str,
+    analyses: list[dict[str, Any]],
+    score_changes: list[dict[str, Any]],
+) -> EmailData:
+    project_name = settings.PROJECT_NAME
+    subject = f"{project_name} - Weekly digest for {organization_name}"
+    html_content = render_email_template(
+        template_name="weekly_digest.html",
+        context={
+            "project_name": settings.PROJECT_NAME,
+            "organization_name": organization_name,
+            "analyses": analyses,
+            "score_changes": score_changes,
+            "email": email_to,
+            "link": settings.FRONTEND_HOST,
+        },
+    )
+    return EmailData(html_content=html_content, subject=subject)
+
+
 def generate_password_reset_token(email: str) -> str:
     delta = timedelta(hours=settings.EMAIL_RESET_TOKEN_EXPIRE_HOURS)
     now = datetime.now(timezone.utc)